@@ -0,0 +1,212 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. PM 1:40
+//
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DEFAULT_ELASTICSEARCH_FLUSH_SIZE     = 500
+	DEFAULT_ELASTICSEARCH_FLUSH_INTERVAL = time.Second * 5
+	DEFAULT_ELASTICSEARCH_TIMEOUT        = time.Second * 30
+	elasticsearchIndexDateFormat         = "2006.01.02"
+	elasticsearchCloseTimeout            = time.Second * 10
+)
+
+// ElasticsearchConfig is configuration of elasticsearch (or opensearch) sink
+type ElasticsearchConfig struct {
+	Url           string            // cluster url. e.g. http://es:9200
+	IndexPrefix   string            // index is <IndexPrefix>-YYYY.MM.DD. default ProcessName
+	Username      string            // basic auth
+	Password      string            // basic auth
+	ApiKey        string            // encoded api key. used instead of basic auth
+	Headers       map[string]string // additional request headers
+	FlushSize     int               // max documents in one bulk request. default 500
+	FlushInterval time.Duration     // max wait before bulk request. default 5 seconds
+	Timeout       time.Duration     // http request timeout. default 30 seconds
+}
+
+// ElasticsearchSink indexes events as ECS documents with bulk api.
+// items rejected by cluster are retried by batch worker when failure is temporary (429, 5xx)
+// and dropped otherwise
+type ElasticsearchSink struct {
+	config ElasticsearchConfig
+	client *http.Client
+	url    string
+	worker *batchWorker
+}
+
+type elasticsearchBulkResponse struct {
+	Errors bool                                       `json:"errors"`
+	Items  []map[string]elasticsearchBulkItemResponse `json:"items"`
+}
+
+type elasticsearchBulkItemResponse struct {
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// NewElasticsearchSink creates elasticsearch sink. cluster does not have to be reachable at creation
+func NewElasticsearchSink(config ElasticsearchConfig) (*ElasticsearchSink, error) {
+	if len(config.Url) == 0 {
+		return nil, errors.New("empty elasticsearch url")
+	}
+	if !strings.HasPrefix(config.Url, "http://") && !strings.HasPrefix(config.Url, "https://") {
+		return nil, fmt.Errorf("invalid elasticsearch url %s", config.Url)
+	}
+	if len(config.IndexPrefix) == 0 {
+		config.IndexPrefix = logPreference.ProcessName
+	}
+	// index name must be lower case
+	config.IndexPrefix = strings.ToLower(config.IndexPrefix)
+	if config.FlushSize < 1 {
+		config.FlushSize = DEFAULT_ELASTICSEARCH_FLUSH_SIZE
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DEFAULT_ELASTICSEARCH_FLUSH_INTERVAL
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DEFAULT_ELASTICSEARCH_TIMEOUT
+	}
+
+	sink := ElasticsearchSink{}
+	sink.config = config
+	sink.client = &http.Client{Timeout: config.Timeout}
+	sink.url = strings.TrimRight(config.Url, "/") + "/_bulk"
+	sink.worker = newBatchWorker(config.FlushSize, config.FlushInterval, sink.send)
+	return &sink, nil
+}
+
+// Write queues event without blocking
+func (sink *ElasticsearchSink) Write(event LogEvent) error {
+	if !sink.worker.add(event) {
+		return errors.New("elasticsearch sink queue is full")
+	}
+	return nil
+}
+
+// Close indexes queued events
+func (sink *ElasticsearchSink) Close() error {
+	if !sink.worker.stop(elasticsearchCloseTimeout) {
+		return errors.New("fail to flush elasticsearch sink")
+	}
+	return nil
+}
+
+// buildIndexName returns date based index name. e.g. proc-2026.10.17
+func (sink *ElasticsearchSink) buildIndexName(t time.Time) string {
+	return sink.config.IndexPrefix + "-" + t.UTC().Format(elasticsearchIndexDateFormat)
+}
+
+// send indexes events with one bulk request. failure of request is retried by batch worker
+// and temporarily rejected items (429, 5xx) are retried alone. other rejected items are dropped
+func (sink *ElasticsearchSink) send(events []LogEvent) error {
+	var body bytes.Buffer
+	for _, event := range events {
+		body.WriteString(`{"create":{"_index":`)
+		index, _ := json.Marshal(sink.buildIndexName(event.Time()))
+		body.Write(index)
+		body.WriteString("}}\n")
+		body.Write(encodeECS(event))
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sink.url, &body)
+	if err != nil {
+		return noRetry(err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if len(sink.config.ApiKey) > 0 {
+		req.Header.Set("Authorization", "ApiKey "+sink.config.ApiKey)
+	} else if len(sink.config.Username) > 0 {
+		req.SetBasicAuth(sink.config.Username, sink.config.Password)
+	}
+	for k, v := range sink.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := sink.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkHttpResponse(resp); err != nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
+
+	var response elasticsearchBulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		// request is accepted. do not send again
+		return nil
+	}
+	if !response.Errors {
+		return nil
+	}
+
+	retry := make([]LogEvent, 0)
+	var rejected uint64
+	var firstRejected elasticsearchBulkItemResponse
+	for i, item := range response.Items {
+		if i >= len(events) {
+			break
+		}
+		for _, result := range item {
+			switch {
+			case result.Status < 300:
+			case result.Status == http.StatusTooManyRequests || result.Status >= 500:
+				retry = append(retry, events[i])
+			default:
+				if rejected == 0 {
+					firstRejected = result
+				}
+				rejected++
+			}
+		}
+	}
+
+	if rejected > 0 {
+		atomic.AddUint64(&loggerStats.DeliveryDropped, rejected)
+		if len(retry) == 0 {
+			atomic.AddUint64(&loggerStats.DeliveryFailures, 1)
+		}
+		fmt.Fprintf(getConsoleFile(), "elasticsearch rejected %d documents. first (%d) : %s\n",
+			rejected, firstRejected.Status, string(firstRejected.Error))
+	}
+	if len(retry) > 0 {
+		return retryOnly(retry, fmt.Errorf("elasticsearch could not index %d documents temporarily", len(retry)))
+	}
+	return nil
+}
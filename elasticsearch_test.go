@@ -0,0 +1,166 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 10:10
+//
+
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type elasticsearchBulkRequest struct {
+	indices  []string
+	messages []string
+}
+
+func parseBulkRequest(t *testing.T, r *http.Request) elasticsearchBulkRequest {
+	request := elasticsearchBulkRequest{}
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		action := make(map[string]map[string]string)
+		if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+			t.Errorf("invalid action line : %s", scanner.Text())
+			break
+		}
+		if !scanner.Scan() {
+			t.Errorf("document line is missing")
+			break
+		}
+		document := make(map[string]interface{})
+		if err := json.Unmarshal(scanner.Bytes(), &document); err != nil {
+			t.Errorf("invalid document line : %s", scanner.Text())
+			break
+		}
+		request.indices = append(request.indices, action["create"]["_index"])
+		message, _ := document["message"].(string)
+		request.messages = append(request.messages, message)
+	}
+	return request
+}
+
+func TestElasticsearchSinkRetriesOnlyTemporarilyRejectedItems(t *testing.T) {
+	var mutex sync.Mutex
+	requests := make([]elasticsearchBulkRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		if username, password, ok := r.BasicAuth(); !ok || username != "elastic" || password != "secret" {
+			t.Errorf("basic auth is missing")
+		}
+
+		request := parseBulkRequest(t, r)
+		mutex.Lock()
+		requests = append(requests, request)
+		mutex.Unlock()
+
+		items := make([]string, 0)
+		for _, message := range request.messages {
+			status := 201
+			switch message {
+			case "busy":
+				if len(requests) == 1 {
+					status = 429
+				}
+			case "mapping":
+				status = 400
+			}
+			items = append(items, fmt.Sprintf(`{"create":{"status":%d}}`, status))
+		}
+		fmt.Fprintf(w, `{"errors":true,"items":[%s]}`, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	sink, err := NewElasticsearchSink(ElasticsearchConfig{
+		Url:           server.URL + "/",
+		IndexPrefix:   "QueryMan",
+		Username:      "elastic",
+		Password:      "secret",
+		FlushInterval: time.Millisecond * 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dropped := atomic.LoadUint64(&loggerStats.DeliveryDropped)
+
+	for _, message := range []string{"indexed", "busy", "mapping"} {
+		if err := sink.Write(newTestEvent(LOG_INFO, nil, message)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 bulk requests, got %d", len(requests))
+	}
+	if len(requests[0].messages) != 3 {
+		t.Errorf("first request should carry every document, got %v", requests[0].messages)
+	}
+	if index := requests[0].indices[0]; !strings.HasPrefix(index, "queryman-") {
+		t.Errorf("unexpected index %s", index)
+	}
+	if retried := requests[1].messages; len(retried) != 1 || retried[0] != "busy" {
+		t.Errorf("only 429 document should be retried, got %v", retried)
+	}
+	if n := atomic.LoadUint64(&loggerStats.DeliveryDropped) - dropped; n != 1 {
+		t.Errorf("rejected document should be counted as dropped, got %d", n)
+	}
+}
+
+func TestElasticsearchSinkDropsOnlyRejectedItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errors":true,"items":[{"create":{"status":201}},{"create":{"status":400,"error":{"type":"mapper_parsing_exception"}}}]}`)
+	}))
+	defer server.Close()
+
+	sink, err := NewElasticsearchSink(ElasticsearchConfig{Url: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	failures := atomic.LoadUint64(&loggerStats.DeliveryFailures)
+	dropped := atomic.LoadUint64(&loggerStats.DeliveryDropped)
+
+	err = sink.send([]LogEvent{newTestEvent(LOG_INFO, nil, "indexed"), newTestEvent(LOG_INFO, nil, "mapping")})
+	if err != nil {
+		t.Errorf("permanently rejected documents should not be retried, got %v", err)
+	}
+	if n := atomic.LoadUint64(&loggerStats.DeliveryFailures) - failures; n != 1 {
+		t.Errorf("expected 1 delivery failure, got %d", n)
+	}
+	if n := atomic.LoadUint64(&loggerStats.DeliveryDropped) - dropped; n != 1 {
+		t.Errorf("expected 1 dropped document, got %d", n)
+	}
+}
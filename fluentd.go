@@ -0,0 +1,262 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 11:30
+//
+
+package log
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_FLUENTD_ADDRESS      = "127.0.0.1:24224"
+	DEFAULT_FLUENTD_BATCH_SIZE   = 100
+	DEFAULT_FLUENTD_WINDOW       = time.Second
+	DEFAULT_FLUENTD_DIAL_TIMEOUT = time.Second * 5
+	DEFAULT_FLUENTD_ACK_TIMEOUT  = time.Second * 10
+	fluentdCloseTimeout          = time.Second * 5
+)
+
+// FluentdConfig is configuration of fluentd forward protocol sink
+type FluentdConfig struct {
+	Network     string        // "tcp" or "unix". default tcp
+	Address     string        // forwarder host:port or socket path. default 127.0.0.1:24224
+	TagPrefix   string        // tag is <TagPrefix>.<level>. default ProcessName
+	BatchSize   int           // max entries of one batch. default 100
+	Window      time.Duration // max wait before sending batch. default 1 second
+	RequireAck  bool          // request ack with chunk id and resend batch when ack is not received
+	AckTimeout  time.Duration // default 10 seconds
+	DialTimeout time.Duration // default 5 seconds
+}
+
+// FluentdSink sends events to fluentd/fluent-bit with forward protocol.
+// events are batched as PackedForward message per tag (e.g. myproc.error)
+type FluentdSink struct {
+	config  FluentdConfig
+	worker  *batchWorker
+	conn    net.Conn
+	unacked map[string]fluentdChunk
+}
+
+// fluentdChunk is PackedForward message of which ack is not received.
+// it is resent with the same chunk id so that forwarder can deduplicate it
+type fluentdChunk struct {
+	id      string
+	entries []byte
+}
+
+// NewFluentdSink creates fluentd sink. forwarder does not have to be reachable at creation
+func NewFluentdSink(config FluentdConfig) (*FluentdSink, error) {
+	if len(config.Network) == 0 {
+		config.Network = "tcp"
+	}
+	switch config.Network {
+	case "tcp", "tcp4", "tcp6", "unix":
+	default:
+		return nil, fmt.Errorf("unsupported network %s", config.Network)
+	}
+	if len(config.Address) == 0 {
+		config.Address = DEFAULT_FLUENTD_ADDRESS
+	}
+	if len(config.TagPrefix) == 0 {
+		config.TagPrefix = logPreference.ProcessName
+	}
+	if config.BatchSize < 1 {
+		config.BatchSize = DEFAULT_FLUENTD_BATCH_SIZE
+	}
+	if config.Window <= 0 {
+		config.Window = DEFAULT_FLUENTD_WINDOW
+	}
+	if config.AckTimeout <= 0 {
+		config.AckTimeout = DEFAULT_FLUENTD_ACK_TIMEOUT
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = DEFAULT_FLUENTD_DIAL_TIMEOUT
+	}
+
+	sink := FluentdSink{}
+	sink.config = config
+	sink.worker = newBatchWorker(config.BatchSize, config.Window, sink.send)
+	return &sink, nil
+}
+
+// Write queues event without blocking
+func (sink *FluentdSink) Write(event LogEvent) error {
+	if !sink.worker.add(event) {
+		return errors.New("fluentd sink queue is full")
+	}
+	return nil
+}
+
+// Close sends queued events and closes connection
+func (sink *FluentdSink) Close() error {
+	flushed := sink.worker.stop(fluentdCloseTimeout)
+	sink.disconnect()
+	if !flushed {
+		return errors.New("fail to flush fluentd sink")
+	}
+	return nil
+}
+
+// send writes one PackedForward message per tag keeping event order within tag.
+// when a tag fails, events of that tag and following tags are retried
+func (sink *FluentdSink) send(events []LogEvent) error {
+	tags := make([]string, 0)
+	grouped := make(map[string][]LogEvent)
+	for _, event := range events {
+		tag := sink.buildTag(event.Level())
+		if _, ok := grouped[tag]; !ok {
+			tags = append(tags, tag)
+		}
+		grouped[tag] = append(grouped[tag], event)
+	}
+
+	for i, tag := range tags {
+		entries := msgpackEncoder{}
+		for _, event := range grouped[tag] {
+			entries.encodeArrayHeader(2)
+			entries.encode(msgpackEventTime(event.Time()))
+			entries.encode(buildFluentdRecord(event))
+		}
+
+		if err := sink.sendPackedForward(tag, entries.bytes(), len(grouped[tag])); err != nil {
+			sink.disconnect()
+			remaining := make([]LogEvent, 0)
+			for _, t := range tags[i:] {
+				remaining = append(remaining, grouped[t]...)
+			}
+			return retryOnly(remaining, err)
+		}
+	}
+	return nil
+}
+
+func (sink *FluentdSink) sendPackedForward(tag string, entries []byte, count int) error {
+	if err := sink.ensureConnected(); err != nil {
+		return err
+	}
+
+	option := map[string]interface{}{"size": count}
+	var chunk string
+	if sink.config.RequireAck {
+		chunk = sink.chunkId(tag, entries)
+		option["chunk"] = chunk
+	}
+
+	// [tag, entries(bin), option]
+	message := msgpackEncoder{}
+	message.encodeArrayHeader(3)
+	message.encode(tag)
+	message.encode(entries)
+	message.encode(option)
+
+	sink.conn.SetWriteDeadline(time.Now().Add(sink.config.DialTimeout))
+	if _, err := sink.conn.Write(message.bytes()); err != nil {
+		return err
+	}
+	if !sink.config.RequireAck {
+		return nil
+	}
+
+	sink.conn.SetReadDeadline(time.Now().Add(sink.config.AckTimeout))
+	response, err := decodeMsgpack(sink.conn)
+	if err != nil {
+		return fmt.Errorf("fail to read fluentd ack : %s", err.Error())
+	}
+	if m, ok := response.(map[string]interface{}); !ok || m["ack"] != chunk {
+		return fmt.Errorf("unexpected fluentd ack : %v", response)
+	}
+	delete(sink.unacked, tag)
+	return nil
+}
+
+// chunkId returns chunk id of unacked message when the same entries are resent, or new chunk id
+func (sink *FluentdSink) chunkId(tag string, entries []byte) string {
+	if chunk, ok := sink.unacked[tag]; ok && bytes.Equal(chunk.entries, entries) {
+		return chunk.id
+	}
+
+	if sink.unacked == nil {
+		sink.unacked = make(map[string]fluentdChunk)
+	}
+	chunk := fluentdChunk{id: newFluentdChunkId(), entries: entries}
+	sink.unacked[tag] = chunk
+	return chunk.id
+}
+
+func (sink *FluentdSink) ensureConnected() error {
+	if sink.conn != nil {
+		return nil
+	}
+
+	conn, err := net.DialTimeout(sink.config.Network, sink.config.Address, sink.config.DialTimeout)
+	if err != nil {
+		return err
+	}
+	sink.conn = conn
+	return nil
+}
+
+func (sink *FluentdSink) disconnect() {
+	if sink.conn != nil {
+		sink.conn.Close()
+		sink.conn = nil
+	}
+}
+
+// buildTag returns tag such as myproc.error
+func (sink *FluentdSink) buildTag(level LogLevel) string {
+	return sink.config.TagPrefix + "." + strings.ToLower(level.String())
+}
+
+// buildFluentdRecord builds record with the same keys as json output except time
+func buildFluentdRecord(event LogEvent) map[string]interface{} {
+	record := make(map[string]interface{})
+	for k, v := range event.Fields() {
+		record[k] = v
+	}
+	record["level"] = event.Level().String()
+	record["process"] = logPreference.ProcessName
+	record["message"] = event.Message()
+	record["file"] = event.File()
+	record["line"] = event.Line()
+	record["function"] = event.Function()
+	if event.Err() != nil {
+		record["error"] = event.Err().Error()
+		record["error_type"] = errorTypeName(event.Err())
+		record["trace"] = buildTraceLines(event.Trace())
+	}
+	return record
+}
+
+func newFluentdChunkId() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return base64.StdEncoding.EncodeToString(id)
+}
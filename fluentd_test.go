@@ -0,0 +1,226 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 10:10
+//
+
+package log
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+)
+
+type fluentdMessage struct {
+	tag     string
+	entries []interface{}
+	chunk   string
+}
+
+// fluentdStandIn accepts forward protocol messages. messages of dropTag are not acked once
+func fluentdStandIn(t *testing.T, dropTag string) (net.Listener, chan fluentdMessage) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received := make(chan fluentdMessage, 16)
+	dropped := false
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			for {
+				value, err := decodeMsgpack(conn)
+				if err != nil {
+					conn.Close()
+					break
+				}
+				message := parseFluentdMessage(t, value)
+				received <- message
+				if message.tag == dropTag && !dropped {
+					dropped = true
+					conn.Close()
+					break
+				}
+				ack := msgpackEncoder{}
+				ack.encode(map[string]interface{}{"ack": message.chunk})
+				conn.Write(ack.bytes())
+			}
+		}
+	}()
+	return listener, received
+}
+
+func parseFluentdMessage(t *testing.T, value interface{}) fluentdMessage {
+	fields, ok := value.([]interface{})
+	if !ok || len(fields) != 3 {
+		t.Errorf("unexpected message %v", value)
+		return fluentdMessage{}
+	}
+
+	message := fluentdMessage{}
+	message.tag, _ = fields[0].(string)
+	option, _ := fields[2].(map[string]interface{})
+	message.chunk, _ = option["chunk"].(string)
+	packed, _ := fields[1].([]byte)
+	reader := bytes.NewReader(packed)
+	for reader.Len() > 0 {
+		entry, err := decodeMsgpack(reader)
+		if err != nil {
+			t.Errorf("fail to decode entries : %s", err.Error())
+			break
+		}
+		message.entries = append(message.entries, entry)
+	}
+	if size, _ := option["size"].(int64); int(size) != len(message.entries) {
+		t.Errorf("size option %v, entries %d", option["size"], len(message.entries))
+	}
+	return message
+}
+
+func TestFluentdSinkSendsPackedForwardPerTag(t *testing.T) {
+	listener, received := fluentdStandIn(t, "")
+	defer listener.Close()
+
+	sink, err := NewFluentdSink(FluentdConfig{Address: listener.Addr().String(), TagPrefix: "test", RequireAck: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	events := []LogEvent{
+		newTestEvent(LOG_INFO, nil, "first"),
+		newTestEvent(LOG_ERROR, nil, "failed", errors.New("boom")),
+		newTestEvent(LOG_INFO, Fields{"user": "jin"}, "second"),
+	}
+	if err := sink.send(events); err != nil {
+		t.Fatal(err)
+	}
+
+	info, failed := <-received, <-received
+	if info.tag != "test.info" || len(info.entries) != 2 {
+		t.Fatalf("unexpected info message %s with %d entries", info.tag, len(info.entries))
+	}
+	entry, _ := info.entries[1].([]interface{})
+	record, _ := entry[1].(map[string]interface{})
+	if record["message"] != "second" || record["user"] != "jin" || record["level"] != "INFO" {
+		t.Errorf("unexpected record %v", record)
+	}
+
+	if failed.tag != "test.error" || len(failed.entries) != 1 {
+		t.Fatalf("unexpected error message %s with %d entries", failed.tag, len(failed.entries))
+	}
+	entry, _ = failed.entries[0].([]interface{})
+	record, _ = entry[1].(map[string]interface{})
+	if record["message"] != "failed" || record["error"] != "boom" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestFluentdSinkFailsWithoutAck(t *testing.T) {
+	listener, _ := fluentdStandIn(t, "test.warn")
+	defer listener.Close()
+
+	sink, err := NewFluentdSink(FluentdConfig{Address: listener.Addr().String(), TagPrefix: "test", RequireAck: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	if err := sink.send([]LogEvent{newTestEvent(LOG_WARN, nil, "warn")}); err == nil {
+		t.Errorf("batch without ack should fail to be retried")
+	}
+}
+
+func TestFluentdSinkResendsUnackedTagWithSameChunk(t *testing.T) {
+	listener, received := fluentdStandIn(t, "test.error")
+	defer listener.Close()
+
+	sink, err := NewFluentdSink(FluentdConfig{Address: listener.Addr().String(), TagPrefix: "test", RequireAck: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	events := []LogEvent{
+		newTestEvent(LOG_INFO, nil, "first"),
+		newTestEvent(LOG_ERROR, nil, "failed", errors.New("boom")),
+		newTestEvent(LOG_INFO, Fields{"user": "jin"}, "second"),
+	}
+
+	err = sink.send(events)
+	var partialErr partialError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("expected partial error, got %v", err)
+	}
+	if len(partialErr.remaining) != 1 || partialErr.remaining[0] != events[1] {
+		t.Fatalf("only unacked error event should be retried, got %d events", len(partialErr.remaining))
+	}
+	if err := sink.send(partialErr.remaining); err != nil {
+		t.Fatalf("retry failed : %s", err.Error())
+	}
+
+	info := <-received
+	if info.tag != "test.info" || len(info.entries) != 2 {
+		t.Fatalf("unexpected info message %s with %d entries", info.tag, len(info.entries))
+	}
+	entry, _ := info.entries[1].([]interface{})
+	record, _ := entry[1].(map[string]interface{})
+	if record["message"] != "second" || record["user"] != "jin" || record["level"] != "INFO" {
+		t.Errorf("unexpected record %v", record)
+	}
+
+	first, retried := <-received, <-received
+	if first.tag != "test.error" || retried.tag != "test.error" {
+		t.Fatalf("unexpected tags %s, %s", first.tag, retried.tag)
+	}
+	if len(first.chunk) == 0 || first.chunk != retried.chunk {
+		t.Errorf("retry should reuse chunk id. first %q, retried %q", first.chunk, retried.chunk)
+	}
+	if len(sink.unacked) != 0 {
+		t.Errorf("acked chunks should be forgotten, %d left", len(sink.unacked))
+	}
+}
+
+func TestFluentdSinkUsesNewChunkForNewBatch(t *testing.T) {
+	listener, received := fluentdStandIn(t, "")
+	defer listener.Close()
+
+	sink, err := NewFluentdSink(FluentdConfig{Address: listener.Addr().String(), TagPrefix: "test", RequireAck: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := sink.send([]LogEvent{newTestEvent(LOG_WARN, nil, "warn")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first, second := <-received, <-received
+	if first.chunk == second.chunk {
+		t.Errorf("each batch should have its own chunk id")
+	}
+}
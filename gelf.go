@@ -0,0 +1,324 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 9:10
+//
+
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DEFAULT_GELF_CHUNK_SIZE  = 1420
+	DEFAULT_GELF_BUFFER_SIZE = 1024
+	gelfChunkHeaderSize      = 12
+	gelfMaxChunks            = 128
+	gelfDialTimeout          = time.Second * 5
+	gelfWriteTimeout         = time.Second * 5
+	gelfMinBackoff           = time.Second
+	gelfMaxBackoff           = time.Second * 30
+)
+
+var gelfFieldNamePattern = regexp.MustCompile(`[^\w\.\-]`)
+
+// GelfConfig is configuration of GELF sink
+type GelfConfig struct {
+	Network    string // "udp" (chunked) or "tcp" (null byte delimited). default udp
+	Address    string // graylog input host:port
+	Host       string // GELF host. default os.Hostname()
+	Compress   bool   // gzip compress udp message
+	ChunkSize  int    // max udp datagram size. default 1420
+	BufferSize int    // in-memory buffer of messages. default 1024
+}
+
+// GelfSink sends events to graylog as GELF 1.1 message without blocking callers.
+// messages are dropped while graylog is unreachable
+type GelfSink struct {
+	config    GelfConfig
+	queue     chan []byte
+	closeReq  chan chan struct{}
+	closeOnce sync.Once
+	conn      net.Conn
+	backoff   time.Duration
+	nextDial  time.Time
+}
+
+// NewGelfSink creates GELF sink. graylog does not have to be reachable at creation. register it with AddSink
+func NewGelfSink(config GelfConfig) (*GelfSink, error) {
+	if len(config.Network) == 0 {
+		config.Network = "udp"
+	}
+	switch config.Network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("unsupported network %s", config.Network)
+	}
+	if len(config.Address) == 0 {
+		return nil, errors.New("empty graylog address")
+	}
+	if len(config.Host) == 0 {
+		config.Host, _ = os.Hostname()
+	}
+	if config.ChunkSize <= gelfChunkHeaderSize {
+		config.ChunkSize = DEFAULT_GELF_CHUNK_SIZE
+	}
+	if config.BufferSize < 1 {
+		config.BufferSize = DEFAULT_GELF_BUFFER_SIZE
+	}
+
+	sink := GelfSink{}
+	sink.config = config
+	sink.queue = make(chan []byte, config.BufferSize)
+	sink.closeReq = make(chan chan struct{})
+	go sink.run()
+	return &sink, nil
+}
+
+func (sink *GelfSink) isUdp() bool {
+	return strings.HasPrefix(sink.config.Network, "udp")
+}
+
+// Write encodes event and queues it without blocking
+func (sink *GelfSink) Write(event LogEvent) error {
+	message := encodeGelf(event, sink.config.Host)
+	if sink.isUdp() && sink.config.Compress {
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		writer.Write(message)
+		writer.Close()
+		message = buffer.Bytes()
+	}
+	if sink.isUdp() && gelfChunkCount(message, sink.config.ChunkSize) > gelfMaxChunks {
+		atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+		return fmt.Errorf("gelf message of %d bytes exceeds %d chunks", len(message), gelfMaxChunks)
+	}
+
+	select {
+	case sink.queue <- message:
+		return nil
+	default:
+		atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+		return errors.New("gelf sink buffer is full")
+	}
+}
+
+// Close sends buffered messages and closes connection. later calls do nothing
+func (sink *GelfSink) Close() error {
+	sink.closeOnce.Do(func() {
+		done := make(chan struct{})
+		sink.closeReq <- done
+		<-done
+	})
+	return nil
+}
+
+func (sink *GelfSink) run() {
+	for {
+		select {
+		case message := <-sink.queue:
+			sink.handle(message)
+		case done := <-sink.closeReq:
+			sink.drain()
+			if sink.conn != nil {
+				sink.conn.Close()
+			}
+			close(done)
+			return
+		}
+	}
+}
+
+func (sink *GelfSink) drain() {
+	for {
+		select {
+		case message := <-sink.queue:
+			sink.handle(message)
+		default:
+			return
+		}
+	}
+}
+
+// handle sends message. when socket went away, it reconnects once and resends
+func (sink *GelfSink) handle(message []byte) {
+	for i := 0; i < 2; i++ {
+		sink.ensureConnected()
+		if sink.conn == nil {
+			break
+		}
+		if err := sink.send(message); err == nil {
+			return
+		}
+		sink.disconnect()
+	}
+	atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+}
+
+// ensureConnected dials graylog when backoff passed
+func (sink *GelfSink) ensureConnected() {
+	if sink.conn != nil || time.Now().Before(sink.nextDial) {
+		return
+	}
+
+	conn, err := net.DialTimeout(sink.config.Network, sink.config.Address, gelfDialTimeout)
+	if err != nil {
+		atomic.AddUint64(&loggerStats.DeliveryFailures, 1)
+		if sink.backoff == 0 {
+			sink.backoff = gelfMinBackoff
+		} else if sink.backoff < gelfMaxBackoff {
+			sink.backoff *= 2
+			if sink.backoff > gelfMaxBackoff {
+				sink.backoff = gelfMaxBackoff
+			}
+		}
+		sink.nextDial = time.Now().Add(sink.backoff)
+		return
+	}
+	sink.conn = conn
+	sink.backoff = 0
+}
+
+func (sink *GelfSink) disconnect() {
+	atomic.AddUint64(&loggerStats.DeliveryFailures, 1)
+	sink.conn.Close()
+	sink.conn = nil
+}
+
+func (sink *GelfSink) send(message []byte) error {
+	sink.conn.SetWriteDeadline(time.Now().Add(gelfWriteTimeout))
+	if !sink.isUdp() {
+		// tcp input does not support compression. message is terminated by null byte
+		_, err := sink.conn.Write(append(message, 0))
+		return err
+	}
+
+	if len(message) <= sink.config.ChunkSize {
+		_, err := sink.conn.Write(message)
+		return err
+	}
+
+	for _, chunk := range buildGelfChunks(message, sink.config.ChunkSize) {
+		if _, err := sink.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildGelfChunks splits message to chunks : magic(2), message id(8), sequence number(1), sequence count(1), data.
+// message should not exceed 128 chunks
+func buildGelfChunks(message []byte, chunkSize int) [][]byte {
+	dataSize := chunkSize - gelfChunkHeaderSize
+	count := gelfChunkCount(message, chunkSize)
+
+	id := make([]byte, 8)
+	rand.Read(id)
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * dataSize
+		if end > len(message) {
+			end = len(message)
+		}
+		chunk := make([]byte, 0, gelfChunkHeaderSize+end-i*dataSize)
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, message[i*dataSize:end]...)
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func gelfChunkCount(message []byte, chunkSize int) int {
+	dataSize := chunkSize - gelfChunkHeaderSize
+	return (len(message) + dataSize - 1) / dataSize
+}
+
+// encodeGelf encodes event as GELF 1.1 json. error and trace go to full_message,
+// source, process and fields go to additional fields
+func encodeGelf(event LogEvent, host string) []byte {
+	object := newJsonObject()
+	object.add("version", "1.1")
+	object.add("host", host)
+	object.add("short_message", event.Message())
+	if event.Err() != nil {
+		object.add("full_message", buildGelfFullMessage(event))
+	}
+	object.add("timestamp", float64(event.Time().UnixNano()/int64(time.Microsecond))/1e6)
+	object.add("level", toSyslogSeverity(event.Level()))
+	object.add("_level_name", event.Level().String())
+	object.add("_process", logPreference.ProcessName)
+	object.add("_file", event.File())
+	object.add("_line", event.Line())
+	object.add("_function", event.Function())
+	if event.Err() != nil {
+		object.add("_error", event.Err().Error())
+		object.add("_error_type", errorTypeName(event.Err()))
+	}
+
+	fields := event.Fields()
+	for _, k := range sortedFieldKeys(fields) {
+		name := toGelfFieldName(k)
+		// _id is reserved by graylog
+		if name == "_id" {
+			name = "_field_id"
+		}
+		object.add(name, toGelfValue(fields[k]))
+	}
+	return object.bytes()
+}
+
+func buildGelfFullMessage(event LogEvent) string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("%s\n(%s) :: %s\n",
+		event.Message(), errorTypeName(event.Err()), event.Err().Error()))
+	for _, line := range buildTraceLines(event.Trace()) {
+		buffer.WriteString("\t")
+		buffer.WriteString(line)
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
+
+func toGelfFieldName(name string) string {
+	return "_" + gelfFieldNamePattern.ReplaceAllString(name, "_")
+}
+
+// toGelfValue keeps numbers and converts others to string. GELF allows only string or number
+func toGelfValue(value interface{}) interface{} {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, string:
+		return value
+	}
+	return fmt.Sprintf("%v", value)
+}
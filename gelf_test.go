@@ -0,0 +1,311 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 11:10
+//
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func decodeTestGelf(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		t.Fatalf("invalid gelf message %q : %s", data, err)
+	}
+	return object
+}
+
+func TestEncodeGelf(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	event := newTestEvent(LOG_ERROR, Fields{"id": 1, "user name": "bob", "tags": []string{"a"}}, "failed", errors.New("boom"))
+
+	object := decodeTestGelf(t, encodeGelf(event, "host1"))
+	expected := map[string]interface{}{
+		"version":       "1.1",
+		"host":          "host1",
+		"short_message": "failed",
+		"level":         float64(3),
+		"_level_name":   "ERROR",
+		"_process":      "test",
+		"_error":        "boom",
+		"_field_id":     float64(1),
+		"_user_name":    "bob",
+		"_tags":         "[a]",
+	}
+	for k, v := range expected {
+		if object[k] != v {
+			t.Errorf("%s = %v, expected %v", k, object[k], v)
+		}
+	}
+	if _, ok := object["_id"]; ok {
+		t.Errorf("_id is reserved by graylog")
+	}
+	full, _ := object["full_message"].(string)
+	if !strings.HasPrefix(full, "failed\n(*errors.errorString) :: boom\n\t") || !strings.Contains(full, "TestEncodeGelf") {
+		t.Errorf("unexpected full message %q", full)
+	}
+	if timestamp, _ := object["timestamp"].(float64); time.Since(time.Unix(int64(timestamp), 0)) > time.Minute {
+		t.Errorf("unexpected timestamp %v", object["timestamp"])
+	}
+}
+
+func TestBuildGelfChunks(t *testing.T) {
+	message := bytes.Repeat([]byte("x"), 25)
+	chunks := buildGelfChunks(message, gelfChunkHeaderSize+10)
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+
+	var joined []byte
+	for i, chunk := range chunks {
+		if chunk[0] != 0x1e || chunk[1] != 0x0f || chunk[10] != byte(i) || chunk[11] != 3 {
+			t.Errorf("unexpected chunk header %v", chunk[:gelfChunkHeaderSize])
+		}
+		if !bytes.Equal(chunk[2:10], chunks[0][2:10]) {
+			t.Errorf("chunks should have same message id")
+		}
+		joined = append(joined, chunk[gelfChunkHeaderSize:]...)
+	}
+	if !bytes.Equal(joined, message) {
+		t.Errorf("chunks should be joined to message")
+	}
+}
+
+func TestGelfSinkUdp(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, read := listenTestUdp(t)
+
+	sink, err := NewGelfSink(GelfConfig{Address: address, Host: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, "hello")); err != nil {
+		t.Fatal(err)
+	}
+	if object := decodeTestGelf(t, []byte(read())); object["short_message"] != "hello" || object["level"] != float64(6) {
+		t.Errorf("unexpected message %v", object)
+	}
+}
+
+func TestGelfSinkUdpCompressedChunks(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, read := listenTestUdp(t)
+
+	sink, err := NewGelfSink(GelfConfig{Address: address, Host: "host1", Compress: true, ChunkSize: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	// random looking message is not compressed under chunk size
+	var message bytes.Buffer
+	for i := 0; i < 100; i++ {
+		message.WriteString(time.Duration(i * 7919).String())
+	}
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, message.String())); err != nil {
+		t.Fatal(err)
+	}
+
+	var compressed []byte
+	for {
+		chunk := []byte(read())
+		if chunk[0] != 0x1e || chunk[1] != 0x0f {
+			t.Fatalf("expected chunked message, got %q", chunk)
+		}
+		compressed = append(compressed, chunk[gelfChunkHeaderSize:]...)
+		if chunk[10] == chunk[11]-1 {
+			break
+		}
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if object := decodeTestGelf(t, data); object["short_message"] != message.String() {
+		t.Errorf("unexpected message %v", object["short_message"])
+	}
+}
+
+func TestGelfSinkDropsMessageOverMaxChunks(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, _ := listenTestUdp(t)
+
+	sink, err := NewGelfSink(GelfConfig{Address: address, Host: "host1", ChunkSize: gelfChunkHeaderSize + 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	dropped := atomic.LoadUint64(&loggerStats.DeliveryDropped)
+
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, strings.Repeat("x", gelfMaxChunks*10))); err == nil {
+		t.Errorf("message over %d chunks should be dropped", gelfMaxChunks)
+	}
+	if n := atomic.LoadUint64(&loggerStats.DeliveryDropped) - dropped; n != 1 {
+		t.Errorf("expected 1 dropped message, got %d", n)
+	}
+}
+
+func TestGelfSinkTcp(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	messages := make(chan []byte, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			message, err := reader.ReadBytes(0)
+			if err != nil {
+				return
+			}
+			messages <- message[:len(message)-1]
+		}
+	}()
+
+	sink, err := NewGelfSink(GelfConfig{Network: "tcp", Address: listener.Addr().String(), Host: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_INFO, nil, "first"))
+	sink.Write(newTestEvent(LOG_WARN, nil, "second"))
+	for _, expected := range []string{"first", "second"} {
+		select {
+		case message := <-messages:
+			if object := decodeTestGelf(t, message); object["short_message"] != expected {
+				t.Errorf("expected %s, got %v", expected, object)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no message received")
+		}
+	}
+}
+
+func TestGelfSinkConnectsInBackground(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	// graylog is not reachable yet
+	sink, err := NewGelfSink(GelfConfig{Network: "tcp", Address: address, Host: "host1"})
+	if err != nil {
+		t.Fatalf("unreachable graylog should not fail sink creation : %s", err)
+	}
+	defer sink.Close()
+
+	dropped := GetStats().DeliveryDropped
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, "lost")); err != nil {
+		t.Fatalf("write should only queue message : %s", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for GetStats().DeliveryDropped == dropped && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if GetStats().DeliveryDropped == dropped {
+		t.Fatalf("message should be dropped while graylog is unreachable")
+	}
+
+	listener, err = net.Listen("tcp", address)
+	if err != nil {
+		t.Skipf("address is taken : %s", err)
+	}
+	defer listener.Close()
+	messages := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if message, err := bufio.NewReader(conn).ReadBytes(0); err == nil {
+			messages <- message[:len(message)-1]
+		}
+	}()
+
+	// next dial waits backoff
+	time.Sleep(gelfMinBackoff + 100*time.Millisecond)
+	sink.Write(newTestEvent(LOG_INFO, nil, "back"))
+	select {
+	case message := <-messages:
+		if object := decodeTestGelf(t, message); object["short_message"] != "back" {
+			t.Errorf("unexpected message %v", object)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("message should be sent after graylog is back")
+	}
+}
+
+func TestGelfSinkCloseTwice(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, _ := listenTestUdp(t)
+
+	sink, err := NewGelfSink(GelfConfig{Address: address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.Close()
+	if err := sink.Close(); err != nil {
+		t.Errorf("second close should do nothing : %s", err)
+	}
+}
+
+func TestGelfSinkInvalidConfig(t *testing.T) {
+	if _, err := NewGelfSink(GelfConfig{Network: "unix", Address: "/tmp/sock"}); err == nil {
+		t.Errorf("unsupported network should be rejected")
+	}
+	if _, err := NewGelfSink(GelfConfig{}); err == nil {
+		t.Errorf("empty address should be rejected")
+	}
+}
@@ -0,0 +1,128 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 9:50
+//
+
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	DEFAULT_JOURNALD_SOCKET = "/run/systemd/journal/socket"
+	journalFieldPrefix      = "FIELD_"
+)
+
+// journalReservedFields are written by sink or have special meaning to journald.
+// user field with same name is written with FIELD_ prefix
+var journalReservedFields = map[string]bool{
+	"MESSAGE": true, "MESSAGE_ID": true, "PRIORITY": true, "TRACE": true,
+	"CODE_FILE": true, "CODE_LINE": true, "CODE_FUNC": true, "ERRNO": true,
+	"SYSLOG_FACILITY": true, "SYSLOG_IDENTIFIER": true, "SYSLOG_PID": true, "SYSLOG_TIMESTAMP": true, "SYSLOG_RAW": true,
+	"INVOCATION_ID": true, "USER_INVOCATION_ID": true, "DOCUMENTATION": true, "TID": true, "UNIT": true, "USER_UNIT": true,
+}
+
+// JournaldConfig is configuration of journald sink
+type JournaldConfig struct {
+	SocketPath string // default /run/systemd/journal/socket
+	Identifier string // SYSLOG_IDENTIFIER. default ProcessName
+}
+
+// buildJournalMessage serializes event with journald native protocol
+func buildJournalMessage(identifier string, event LogEvent) []byte {
+	var buffer bytes.Buffer
+
+	message := event.Message()
+	if event.Err() != nil {
+		message = fmt.Sprintf("%s : %s", message, event.Err().Error())
+	}
+	writeJournalField(&buffer, "MESSAGE", message)
+	writeJournalField(&buffer, "PRIORITY", strconv.Itoa(toSyslogSeverity(event.Level())))
+	writeJournalField(&buffer, "SYSLOG_IDENTIFIER", identifier)
+	writeJournalField(&buffer, "CODE_FILE", event.File())
+	writeJournalField(&buffer, "CODE_LINE", strconv.Itoa(event.Line()))
+	writeJournalField(&buffer, "CODE_FUNC", event.Function())
+
+	if len(event.Trace()) > 0 {
+		var trace bytes.Buffer
+		for _, point := range event.Trace() {
+			trace.WriteString(fmt.Sprintf("%s (%s:%d)\n", point.Function(), point.File(), point.Line()))
+		}
+		writeJournalField(&buffer, "TRACE", trace.String())
+	}
+
+	fields := event.Fields()
+	for _, k := range sortedFieldKeys(fields) {
+		name := toJournalFieldName(k)
+		if journalReservedFields[name] {
+			name = journalFieldPrefix + name
+		}
+		if len(name) > 0 {
+			writeJournalField(&buffer, name, fmt.Sprintf("%v", fields[k]))
+		}
+	}
+	return buffer.Bytes()
+}
+
+// writeJournalField writes KEY=value line. value with newline is written in binary form
+func writeJournalField(buffer *bytes.Buffer, name string, value string) {
+	buffer.WriteString(name)
+	if strings.IndexByte(value, '\n') < 0 {
+		buffer.WriteByte('=')
+		buffer.WriteString(value)
+		buffer.WriteByte('\n')
+		return
+	}
+
+	buffer.WriteByte('\n')
+	binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value)
+	buffer.WriteByte('\n')
+}
+
+// toJournalFieldName returns uppercase letters, digits and underscores not starting with underscore or digit
+func toJournalFieldName(name string) string {
+	var buffer bytes.Buffer
+	for _, c := range strings.ToUpper(name) {
+		if buffer.Len() >= 64 {
+			break
+		}
+		switch {
+		case c >= 'A' && c <= 'Z':
+			buffer.WriteRune(c)
+		case c >= '0' && c <= '9', c == '_':
+			// field name can not start with underscore(trusted field) or digit
+			if buffer.Len() > 0 {
+				buffer.WriteRune(c)
+			}
+		default:
+			if buffer.Len() > 0 {
+				buffer.WriteByte('_')
+			}
+		}
+	}
+	return buffer.String()
+}
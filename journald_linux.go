@@ -0,0 +1,144 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 9:50
+//
+
+//go:build linux
+// +build linux
+
+package log
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// JournaldSink writes events to journald via native datagram protocol
+type JournaldSink struct {
+	identifier string
+	mutex      sync.Mutex
+	conn       *net.UnixConn
+}
+
+// NewJournaldSink connects to journald socket. register it with AddSink
+func NewJournaldSink(config JournaldConfig) (*JournaldSink, error) {
+	if len(config.SocketPath) == 0 {
+		config.SocketPath = DEFAULT_JOURNALD_SOCKET
+	}
+	if len(config.Identifier) == 0 {
+		config.Identifier = logPreference.ProcessName
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: config.SocketPath, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	sink := JournaldSink{}
+	sink.identifier = config.Identifier
+	sink.conn = conn
+	return &sink, nil
+}
+
+func (sink *JournaldSink) Write(event LogEvent) error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	if sink.conn == nil {
+		return errors.New("journald sink closed")
+	}
+
+	data := buildJournalMessage(sink.identifier, event)
+	_, err := sink.conn.Write(data)
+	if err == nil {
+		return nil
+	}
+	if !isMessageTooLarge(err) {
+		return err
+	}
+
+	return sink.writeWithFileDescriptor(data)
+}
+
+// large message goes through memory backed /dev/shm or local /var/tmp. $TMPDIR is not used
+var journalTempDirs = []string{"/dev/shm", "/var/tmp"}
+
+// writeWithFileDescriptor passes large message as file descriptor of unlinked temporary file
+func (sink *JournaldSink) writeWithFileDescriptor(data []byte) error {
+	var file *os.File
+	var err error
+	for _, dir := range journalTempDirs {
+		if file, err = ioutil.TempFile(dir, "journal."); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = os.Remove(file.Name()); err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		return err
+	}
+
+	rights := syscall.UnixRights(int(file.Fd()))
+	rawConn, err := sink.conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var sendErr error
+	err = rawConn.Write(func(fd uintptr) bool {
+		sendErr = syscall.Sendmsg(int(fd), nil, rights, nil, 0)
+		return sendErr != syscall.EAGAIN
+	})
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
+
+func (sink *JournaldSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	if sink.conn == nil {
+		return nil
+	}
+	err := sink.conn.Close()
+	sink.conn = nil
+	return err
+}
+
+func isMessageTooLarge(err error) bool {
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		return errno == syscall.EMSGSIZE || errno == syscall.ENOBUFS
+	}
+	return false
+}
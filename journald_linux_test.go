@@ -0,0 +1,151 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 10:00
+//
+
+//go:build linux
+// +build linux
+
+package log
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// listenTestJournal returns journald socket path and listener
+func listenTestJournal(t *testing.T) (string, *net.UnixConn) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return path, conn
+}
+
+func TestJournaldSinkWrite(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	path, conn := listenTestJournal(t)
+
+	sink, err := NewJournaldSink(JournaldConfig{SocketPath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, "hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := make([]byte, 8192)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, err := conn.Read(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := parseJournalMessage(t, buffer[:n])
+	if fields["MESSAGE"] != "hello" || fields["SYSLOG_IDENTIFIER"] != "test" {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
+
+func TestJournaldSinkPassesLargeMessageAsFileDescriptor(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	path, conn := listenTestJournal(t)
+
+	sink, err := NewJournaldSink(JournaldConfig{SocketPath: path, Identifier: "large"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	message := strings.Repeat("x", 4*1024*1024)
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, message)); err != nil {
+		t.Fatal(err)
+	}
+
+	oob := make([]byte, syscall.CmsgSpace(4))
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, oobn, _, _, err := conn.ReadMsgUnix(nil, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("expected file descriptor, got %v %v", messages, err)
+	}
+	fds, err := syscall.ParseUnixRights(&messages[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("expected file descriptor, got %v %v", fds, err)
+	}
+
+	file := os.NewFile(uintptr(fds[0]), "journal")
+	defer file.Close()
+	file.Seek(0, 0)
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := parseJournalMessage(t, data)
+	if fields["MESSAGE"] != message || fields["SYSLOG_IDENTIFIER"] != "large" {
+		t.Errorf("unexpected large message of %d bytes", len(fields["MESSAGE"]))
+	}
+}
+
+func TestJournaldSinkDoesNotUseTmpdir(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	path, _ := listenTestJournal(t)
+
+	tmpdir := t.TempDir()
+	dirs, previous := journalTempDirs, os.Getenv("TMPDIR")
+	journalTempDirs = []string{filepath.Join(tmpdir, "missing")}
+	os.Setenv("TMPDIR", tmpdir)
+	defer func() {
+		journalTempDirs = dirs
+		os.Setenv("TMPDIR", previous)
+	}()
+
+	sink, err := NewJournaldSink(JournaldConfig{SocketPath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, strings.Repeat("x", 4*1024*1024))); err == nil {
+		t.Errorf("large message should not fall back to $TMPDIR")
+	}
+	if entries, _ := ioutil.ReadDir(tmpdir); len(entries) > 0 {
+		t.Errorf("temporary file should not be created in $TMPDIR")
+	}
+}
+
+func TestJournaldSinkMissingSocket(t *testing.T) {
+	if _, err := NewJournaldSink(JournaldConfig{SocketPath: filepath.Join(t.TempDir(), "none.sock")}); err == nil {
+		t.Errorf("missing socket should be rejected")
+	}
+}
@@ -0,0 +1,48 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 9:50
+//
+
+//go:build !linux
+// +build !linux
+
+package log
+
+import (
+	"errors"
+)
+
+// JournaldSink is available only on linux
+type JournaldSink struct {
+}
+
+// NewJournaldSink returns error. journald is available only on linux
+func NewJournaldSink(config JournaldConfig) (*JournaldSink, error) {
+	return nil, errors.New("journald is not supported on this platform")
+}
+
+func (sink *JournaldSink) Write(event LogEvent) error {
+	return errors.New("journald is not supported on this platform")
+}
+
+func (sink *JournaldSink) Close() error {
+	return nil
+}
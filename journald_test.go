@@ -0,0 +1,137 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 10:00
+//
+
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// parseJournalMessage parses native protocol message to fields
+func parseJournalMessage(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	fields := make(map[string]string)
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, '\n')
+		if end < 0 {
+			t.Fatalf("unterminated field %q", data)
+		}
+		line := string(data[:end])
+		data = data[end+1:]
+
+		if i := strings.IndexByte(line, '='); i >= 0 {
+			fields[line[:i]] = line[i+1:]
+			continue
+		}
+		// binary form : name\n, 64bit little endian length, value, \n
+		length := binary.LittleEndian.Uint64(data[:8])
+		fields[line] = string(data[8 : 8+length])
+		data = data[8+length+1:]
+	}
+	return fields
+}
+
+func TestBuildJournalMessage(t *testing.T) {
+	event := newTestEvent(LOG_WARN, Fields{"user.id": 7, "_secret": "x", "9lives": "cat"}, "disk full")
+
+	fields := parseJournalMessage(t, buildJournalMessage("app", event))
+	expected := map[string]string{
+		"MESSAGE":           "disk full",
+		"PRIORITY":          "4",
+		"SYSLOG_IDENTIFIER": "app",
+		"CODE_FUNC":         "throosea.com/log.TestBuildJournalMessage",
+		"USER_ID":           "7",
+		"SECRET":            "x",
+		"LIVES":             "cat",
+	}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("field %s = %q, expected %q", k, fields[k], v)
+		}
+	}
+	if !strings.HasSuffix(fields["CODE_FILE"], "journald_test.go") || len(fields["CODE_LINE"]) == 0 {
+		t.Errorf("unexpected code location %s:%s", fields["CODE_FILE"], fields["CODE_LINE"])
+	}
+	if _, ok := fields["TRACE"]; ok {
+		t.Errorf("event without error should not have trace")
+	}
+}
+
+func TestBuildJournalMessageRenamesReservedFields(t *testing.T) {
+	event := newTestEvent(LOG_INFO, Fields{"message": "forged", "priority": 0, "code_file": "x.go", "syslog_identifier": "other"}, "hello")
+
+	fields := parseJournalMessage(t, buildJournalMessage("app", event))
+	expected := map[string]string{
+		"MESSAGE":                 "hello",
+		"PRIORITY":                "6",
+		"SYSLOG_IDENTIFIER":       "app",
+		"FIELD_MESSAGE":           "forged",
+		"FIELD_PRIORITY":          "0",
+		"FIELD_CODE_FILE":         "x.go",
+		"FIELD_SYSLOG_IDENTIFIER": "other",
+	}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("field %s = %q, expected %q", k, fields[k], v)
+		}
+	}
+	if !strings.HasSuffix(fields["CODE_FILE"], "journald_test.go") {
+		t.Errorf("user field should not override CODE_FILE, got %s", fields["CODE_FILE"])
+	}
+}
+
+func TestBuildJournalMessageMultiline(t *testing.T) {
+	event := newTestEvent(LOG_ERROR, nil, "line1\nline2", errors.New("boom"))
+
+	fields := parseJournalMessage(t, buildJournalMessage("app", event))
+	if fields["MESSAGE"] != "line1\nline2 : boom" || fields["PRIORITY"] != "3" {
+		t.Errorf("unexpected message %q priority %s", fields["MESSAGE"], fields["PRIORITY"])
+	}
+	if !strings.Contains(fields["TRACE"], "TestBuildJournalMessageMultiline (") {
+		t.Errorf("unexpected trace %q", fields["TRACE"])
+	}
+}
+
+func TestToJournalFieldName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"user", "USER"},
+		{"trace-id", "TRACE_ID"},
+		{"_private", "PRIVATE"},
+		{"0day", "DAY"},
+		{"한글", ""},
+		{strings.Repeat("a", 70), strings.Repeat("A", 64)},
+	}
+	for _, test := range tests {
+		if name := toJournalFieldName(test.name); name != test.expected {
+			t.Errorf("toJournalFieldName(%q) = %q, expected %q", test.name, name, test.expected)
+		}
+	}
+}
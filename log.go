@@ -24,8 +24,6 @@
 package log
 
 import (
-	"fmt"
-	"path/filepath"
 	"runtime"
 	"time"
 )
@@ -50,7 +48,14 @@ func Initialize(pref preference)  {
 	loggerStatus = LOGGING_STATUS_RUNNING
 	logPreference = pref
 	normalizePreference(&logPreference)
-	logPreference.logFilePath = fmt.Sprintf("%s.log", filepath.Join(pref.logFolder, pref.ProcessName))
+	mainLogFile = newLogFile(logPreference.ProcessName, &logPreference.KeepingFileDays)
+	if logPreference.ErrorLogFile {
+		errorLogFile = newLogFile(logPreference.ProcessName + ".error", &logPreference.ErrorKeepingFileDays)
+	}
+	logPreference.consoleColor = isColorTerminal(getConsoleFile())
+	if logPreference.Redaction {
+		enableDefaultRedaction()
+	}
 	if logPreference.DeliveryMode == DELIVERY_MODE_ASYNC {
 		go func() {
 			for {
@@ -81,7 +86,7 @@ func SetShowMethod(newValue bool) {
 
 func SetKeepingFileDays(days uint16)	{
 	// minimum keeping file days : 2
-	if days < 2 || logPreference.streamMode == STREAM_MODE_STDOUT {
+	if days < 2 || logPreference.streamMode&STREAM_MODE_FILE == 0 {
 		return
 	}
 
@@ -90,14 +95,30 @@ func SetKeepingFileDays(days uint16)	{
 	if old != days {
 		Info("logging backup days changed to %d", logPreference.KeepingFileDays)
 		go func() {
-			removeOldLogFiles()
+			mainLogFile.removeOldFiles()
+		}()
+	}
+}
+
+func SetErrorKeepingFileDays(days uint16)	{
+	// minimum keeping file days : 2
+	if days < 2 || logPreference.streamMode&STREAM_MODE_FILE == 0 || !logPreference.ErrorLogFile {
+		return
+	}
+
+	var old = logPreference.ErrorKeepingFileDays
+	logPreference.ErrorKeepingFileDays = days
+	if old != days {
+		Info("error logging backup days changed to %d", logPreference.ErrorKeepingFileDays)
+		go func() {
+			errorLogFile.removeOldFiles()
 		}()
 	}
 }
 
 func SetFileSizeLimitMB(mb uint16)	{
 	// minimum file size limit mb : 1
-	if mb < 1 || logPreference.streamMode == STREAM_MODE_STDOUT {
+	if mb < 1 || logPreference.streamMode&STREAM_MODE_FILE == 0 {
 		return
 	}
 
@@ -105,6 +126,11 @@ func SetFileSizeLimitMB(mb uint16)	{
 	Info("[Not yet support] logging file size limit to %d MB", logPreference.LogfileSizeLimitMB)
 }
 
+// SetConsoleLevel sets level threshold of console copy in STREAM_MODE_TEE
+func SetConsoleLevel(level LogLevel) {
+	logPreference.ConsoleLogLevel = level
+}
+
 func SetSentryDsn(dsn string, tags map[string]string)	{
 	logPreference.sentryDsn = dsn
 	logPreference.sentryTag = tags
@@ -120,6 +146,29 @@ func SetSentryLogLevel(logLevel string)	{
 	logPreference.sentryLogLevel = ConvertStringToLogLevel(logLevel)
 }
 
+// SetSentryBreadcrumbLevel sets max level of events recorded as sentry breadcrumbs
+func SetSentryBreadcrumbLevel(logLevel string)	{
+	logPreference.sentryBreadcrumbLevel = ConvertStringToLogLevel(logLevel)
+}
+
+// SetSentryMaxBreadcrumbs sets max count of breadcrumbs carried by sentry event (max 100)
+func SetSentryMaxBreadcrumbs(count int)	{
+	if count > 0 {
+		logPreference.sentryMaxBreadcrumbs = count
+	}
+}
+
+// SetSentryRateLimit sets max events per fingerprint(level, source, kind) and max total events in a minute.
+// 0 means unlimited
+func SetSentryRateLimit(perFingerprint int, perMinute int)	{
+	if perFingerprint >= 0 {
+		logPreference.sentryFingerprintLimit = perFingerprint
+	}
+	if perMinute >= 0 {
+		logPreference.sentryEventsPerMinute = perMinute
+	}
+}
+
 func SetLevel(level LogLevel) {
 	effectiveLogLevel = level
 }
@@ -134,13 +183,28 @@ func Close() error {
 	}
 
 	if logPreference.DeliveryMode == DELIVERY_MODE_SYNC {
+		flushReporters()
+		closeReporters()
+		closeSinks()
 		return nil
 	}
 
+	waitForLogEventDelivered()
+	flushReporters()
+	closeReporters()
+	closeSinks()
+	loggerStatus = LOGGING_STATUS_SHUTDOWN
+	return nil
+}
+
+func waitForLogEventDelivered() {
+	if logPreference.DeliveryMode == DELIVERY_MODE_SYNC {
+		return
+	}
+
 	for {
 		if len(logEventChannel) == 0 && !writingLogEvent {
-			loggerStatus = LOGGING_STATUS_SHUTDOWN
-			return nil
+			return
 		}
 		time.Sleep(time.Millisecond * 1)
 	}
@@ -148,7 +212,9 @@ func Close() error {
 
 
 type customLogger struct{
-	level LogLevel
+	level  LogLevel
+	fields Fields
+	tags   map[string]string
 }
 
 func NewCustomLogger(loglevel string) customLogger {
@@ -162,8 +228,42 @@ func (c customLogger) Printf(format string, a ...interface{}) {
 		var s []interface{}
 		s = append(s, format)
 		s = append(s, a...)
-		print(3, c.level, s...)
+		print(3, c.level, c.fields, c.tags, s...)
+	}
+}
+
+// WithFields returns copy of logger which attaches fields to every event
+func (c customLogger) WithFields(fields Fields) customLogger {
+	if len(fields) == 0 {
+		return c
+	}
+
+	merged := make(Fields)
+	for k, v := range c.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	c.fields = merged
+	return c
+}
+
+// WithTags returns copy of logger which overrides sentry tags of every event
+func (c customLogger) WithTags(tags map[string]string) customLogger {
+	if len(tags) == 0 {
+		return c
+	}
+
+	merged := make(map[string]string)
+	for k, v := range c.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
 	}
+	c.tags = merged
+	return c
 }
 
 func IsErrorEnabled() bool {
@@ -175,7 +275,7 @@ func IsErrorEnabled() bool {
 
 func Error(v ...interface{}) {
 	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_ERROR && len(v) > 0 {
-		print(2, LOG_ERROR, v...)
+		print(2, LOG_ERROR, nil, nil, v...)
 	}
 }
 
@@ -188,7 +288,7 @@ func IsWarnEnabled() bool {
 
 func Warn(v ...interface{}) {
 	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_WARN && len(v) > 0 {
-		print(2, LOG_WARN, v...)
+		print(2, LOG_WARN, nil, nil, v...)
 	}
 }
 
@@ -201,7 +301,7 @@ func IsInfoEnabled() bool {
 
 func Info(v ...interface{}) {
 	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_INFO && len(v) > 0 {
-		print(2, LOG_INFO, v...)
+		print(2, LOG_INFO, nil, nil, v...)
 	}
 }
 
@@ -214,7 +314,7 @@ func IsDebugEnabled() bool {
 
 func Debug(v ...interface{}) {
 	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_DEBUG && len(v) > 0 {
-		print(2, LOG_DEBUG, v...)
+		print(2, LOG_DEBUG, nil, nil, v...)
 	}
 }
 
@@ -227,11 +327,11 @@ func IsTraceEnabled() bool {
 
 func Trace(v ...interface{}) {
 	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_TRACE && len(v) > 0 {
-		print(2, LOG_TRACE, v...)
+		print(2, LOG_TRACE, nil, nil, v...)
 	}
 }
 
-func print(skip int, level LogLevel, v ...interface{}) {
+func print(skip int, level LogLevel, fields Fields, tags map[string]string, v ...interface{}) {
 	pc, file, line, _ := runtime.Caller(skip)
 
 	var logEvent LogEvent
@@ -253,10 +353,24 @@ func print(skip int, level LogLevel, v ...interface{}) {
 
 	logEvent.setLevel(level)
 	logEvent.setArgs(v...)
+	logEvent.setFields(fields)
+	logEvent.setTags(tags)
 
-	if logPreference.DeliveryMode == DELIVERY_MODE_SYNC {
-		writeLogEvent(logEvent)
-	} else {
-		logEventChannel <- logEvent
+	deliver(logEvent)
+}
+
+func deliver(logEvent LogEvent) {
+	// hooks run on caller goroutine in both delivery modes
+	for _, event := range applyHooks(logEvent) {
+		// hook may lower level under current logging level
+		if effectiveLogLevel < event.Level() {
+			continue
+		}
+
+		if logPreference.DeliveryMode == DELIVERY_MODE_SYNC {
+			writeLogEvent(event)
+		} else {
+			logEventChannel <- event
+		}
 	}
 }
@@ -0,0 +1,256 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 8:10
+//
+
+package log
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DEFAULT_BATCH_QUEUE_SIZE  = 1024
+	DEFAULT_BATCH_SIZE        = 100
+	DEFAULT_BATCH_WINDOW      = time.Second * 5
+	DEFAULT_BATCH_MAX_RETRY   = 3
+	DEFAULT_BATCH_MIN_BACKOFF = time.Second
+	DEFAULT_BATCH_MAX_BACKOFF = time.Minute
+)
+
+// noRetryError marks delivery failure which should not be retried (e.g. http 4xx)
+type noRetryError struct {
+	err error
+}
+
+func (e noRetryError) Error() string {
+	return e.err.Error()
+}
+
+func noRetry(err error) error {
+	return noRetryError{err: err}
+}
+
+// partialError marks delivery failure of some events in batch. only remaining events are retried
+type partialError struct {
+	err       error
+	remaining []LogEvent
+}
+
+func (e partialError) Error() string {
+	return e.err.Error()
+}
+
+func (e partialError) Unwrap() error {
+	return e.err
+}
+
+func retryOnly(remaining []LogEvent, err error) error {
+	return partialError{err: err, remaining: remaining}
+}
+
+// batchWorker collects events on its own goroutine and sends them in batch
+// when batch is full or window passed. failed batch is retried with exponential backoff.
+// worker does not take events from queue while it is backing off, so events added meanwhile
+// are dropped when queue is full. stop interrupts backoff
+type batchWorker struct {
+	queue    chan LogEvent
+	flushReq chan chan struct{}
+	stopReq  chan struct{}
+	stopOnce sync.Once
+	stopDone bool
+	size     int
+	window   time.Duration
+	maxRetry int
+	backoff  time.Duration
+	send     func(events []LogEvent) error
+}
+
+func newBatchWorker(size int, window time.Duration, send func(events []LogEvent) error) *batchWorker {
+	if size < 1 {
+		size = DEFAULT_BATCH_SIZE
+	}
+	if window <= 0 {
+		window = DEFAULT_BATCH_WINDOW
+	}
+
+	worker := batchWorker{}
+	worker.queue = make(chan LogEvent, DEFAULT_BATCH_QUEUE_SIZE)
+	worker.flushReq = make(chan chan struct{})
+	worker.stopReq = make(chan struct{})
+	worker.size = size
+	worker.window = window
+	worker.maxRetry = DEFAULT_BATCH_MAX_RETRY
+	worker.send = send
+	go worker.run()
+	return &worker
+}
+
+// add queues event without blocking. returns false when queue is full
+func (worker *batchWorker) add(event LogEvent) bool {
+	select {
+	case worker.queue <- event:
+		return true
+	default:
+		atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+		return false
+	}
+}
+
+// flush sends every queued event and waits within timeout
+func (worker *batchWorker) flush(timeout time.Duration) bool {
+	done := make(chan struct{})
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case worker.flushReq <- done:
+	case <-timer.C:
+		return false
+	}
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// stop flushes queued events within timeout and terminates worker goroutine.
+// later calls return result of the first call
+func (worker *batchWorker) stop(timeout time.Duration) bool {
+	worker.stopOnce.Do(func() {
+		worker.stopDone = worker.flush(timeout)
+		close(worker.stopReq)
+	})
+	return worker.stopDone
+}
+
+// stopTimer stops timer and drains its channel so that stale tick does not fire next window
+func stopTimer(timer *time.Timer) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+}
+
+func (worker *batchWorker) run() {
+	batch := make([]LogEvent, 0, worker.size)
+	timer := time.NewTimer(worker.window)
+	stopTimer(timer)
+
+	for {
+		select {
+		case event := <-worker.queue:
+			batch = append(batch, event)
+			if len(batch) == 1 {
+				timer.Reset(worker.window)
+			}
+			if len(batch) >= worker.size {
+				stopTimer(timer)
+				worker.deliver(batch)
+				batch = make([]LogEvent, 0, worker.size)
+			}
+		case <-timer.C:
+			worker.deliver(batch)
+			batch = make([]LogEvent, 0, worker.size)
+		case done := <-worker.flushReq:
+			stopTimer(timer)
+			batch = worker.drain(batch)
+			close(done)
+		case <-worker.stopReq:
+			stopTimer(timer)
+			return
+		}
+	}
+}
+
+// drain delivers batch and every queued event
+func (worker *batchWorker) drain(batch []LogEvent) []LogEvent {
+	for {
+		select {
+		case event := <-worker.queue:
+			batch = append(batch, event)
+			if len(batch) >= worker.size {
+				worker.deliver(batch)
+				batch = make([]LogEvent, 0, worker.size)
+			}
+		default:
+			worker.deliver(batch)
+			return make([]LogEvent, 0, worker.size)
+		}
+	}
+}
+
+func (worker *batchWorker) deliver(batch []LogEvent) {
+	if len(batch) == 0 {
+		return
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := worker.send(batch)
+		if err == nil {
+			worker.backoff = 0
+			return
+		}
+
+		atomic.AddUint64(&loggerStats.DeliveryFailures, 1)
+		var partialErr partialError
+		if errors.As(err, &partialErr) {
+			batch = partialErr.remaining
+		}
+		var noRetryErr noRetryError
+		if errors.As(err, &noRetryErr) || attempt >= worker.maxRetry {
+			atomic.AddUint64(&loggerStats.DeliveryDropped, uint64(len(batch)))
+			return
+		}
+
+		// exponential backoff
+		if worker.backoff == 0 {
+			worker.backoff = DEFAULT_BATCH_MIN_BACKOFF
+		} else if worker.backoff < DEFAULT_BATCH_MAX_BACKOFF {
+			worker.backoff *= 2
+		}
+		if !worker.sleep(worker.backoff) {
+			atomic.AddUint64(&loggerStats.DeliveryDropped, uint64(len(batch)))
+			return
+		}
+	}
+}
+
+// sleep waits for backoff. returns false when worker is stopped meanwhile
+func (worker *batchWorker) sleep(backoff time.Duration) bool {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-worker.stopReq:
+		return false
+	}
+}
@@ -0,0 +1,122 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 10:00
+//
+
+package log
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchWorkerStopTwice(t *testing.T) {
+	var sent int32
+	worker := newBatchWorker(10, time.Minute, func(events []LogEvent) error {
+		atomic.AddInt32(&sent, int32(len(events)))
+		return nil
+	})
+	worker.add(newTestEvent(LOG_INFO, nil, "queued"))
+
+	if !worker.stop(time.Second) {
+		t.Errorf("first stop should flush queued events")
+	}
+	if !worker.stop(time.Second) {
+		t.Errorf("second stop should return result of first stop")
+	}
+	if atomic.LoadInt32(&sent) != 1 {
+		t.Errorf("expected 1 sent event, got %d", sent)
+	}
+}
+
+func TestBatchWorkerStopInterruptsBackoff(t *testing.T) {
+	worker := newBatchWorker(1, time.Minute, func(events []LogEvent) error {
+		return errors.New("unavailable")
+	})
+	dropped := atomic.LoadUint64(&loggerStats.DeliveryDropped)
+	worker.add(newTestEvent(LOG_INFO, nil, "failing"))
+
+	start := time.Now()
+	worker.stop(100 * time.Millisecond)
+
+	// backoff sleep is interrupted and batch is dropped
+	for time.Since(start) < DEFAULT_BATCH_MIN_BACKOFF/2 {
+		if atomic.LoadUint64(&loggerStats.DeliveryDropped) > dropped {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("stop should interrupt backoff and drop batch")
+}
+
+func TestBatchWorkerSendsAfterWindow(t *testing.T) {
+	batches := make(chan int, 4)
+	worker := newBatchWorker(2, 50*time.Millisecond, func(events []LogEvent) error {
+		batches <- len(events)
+		return nil
+	})
+	defer worker.stop(time.Second)
+
+	// full batch is sent at once and remaining event waits for window
+	for i := 0; i < 3; i++ {
+		worker.add(newTestEvent(LOG_INFO, nil, "event"))
+	}
+	for _, expected := range []int{2, 1} {
+		select {
+		case n := <-batches:
+			if n != expected {
+				t.Errorf("expected batch of %d, got %d", expected, n)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("batch is not sent")
+		}
+	}
+}
+
+func TestBatchWorkerRetriesOnlyRemainingEvents(t *testing.T) {
+	batches := make(chan []LogEvent, 4)
+	worker := newBatchWorker(10, time.Minute, func(events []LogEvent) error {
+		batches <- events
+		if len(events) > 1 {
+			return retryOnly(events[1:2], errors.New("second event is not delivered"))
+		}
+		return nil
+	})
+	defer worker.stop(time.Second)
+
+	events := []LogEvent{newTestEvent(LOG_INFO, nil, "first"), newTestEvent(LOG_INFO, nil, "second"), newTestEvent(LOG_INFO, nil, "third")}
+	for _, event := range events {
+		worker.add(event)
+	}
+	if !worker.flush(2 * DEFAULT_BATCH_MIN_BACKOFF) {
+		t.Fatalf("flush timeout")
+	}
+
+	first, retried := <-batches, <-batches
+	if len(first) != 3 {
+		t.Errorf("first attempt should carry every event, got %d", len(first))
+	}
+	if len(retried) != 1 || retried[0] != events[1] {
+		t.Errorf("only remaining event should be retried, got %d events", len(retried))
+	}
+}
@@ -0,0 +1,94 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 4:05
+//
+
+package log
+
+import (
+	"bytes"
+	"os"
+	"strings"
+)
+
+// ANSI escape sequences
+const (
+	colorReset   = "\x1b[0m"
+	colorDim     = "\x1b[2m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+)
+
+// isColorTerminal returns true when f is terminal and NO_COLOR is not set
+func isColorTerminal(f *os.File) bool {
+	if len(os.Getenv("NO_COLOR")) > 0 || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+func levelColor(level LogLevel) string {
+	switch level {
+	case LOG_ERROR:
+		return colorRed
+	case LOG_WARN:
+		return colorYellow
+	case LOG_INFO:
+		return colorGreen
+	case LOG_DEBUG:
+		return colorCyan
+	case LOG_TRACE:
+		return colorBlue
+	}
+	return colorReset
+}
+
+// colorize wraps every line of s with color so that multi-line block does not bleed
+func colorize(color string, s string) string {
+	if len(s) == 0 {
+		return s
+	}
+
+	var buffer bytes.Buffer
+	lines := strings.SplitAfter(s, "\n")
+	for _, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		body := strings.TrimSuffix(line, "\n")
+		buffer.WriteString(color)
+		buffer.WriteString(body)
+		buffer.WriteString(colorReset)
+		if len(body) < len(line) {
+			buffer.WriteByte('\n')
+		}
+	}
+	return buffer.String()
+}
@@ -0,0 +1,95 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 4:10
+//
+
+package log
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestColorizeWrapsEveryLine(t *testing.T) {
+	colored := colorize(colorRed, "first\nsecond\n")
+	expected := colorRed + "first" + colorReset + "\n" + colorRed + "second" + colorReset + "\n"
+	if colored != expected {
+		t.Errorf("unexpected colored text %q", colored)
+	}
+	if colorize(colorRed, "") != "" {
+		t.Errorf("empty text should stay empty")
+	}
+}
+
+func TestIsColorTerminal(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if isColorTerminal(file) {
+		t.Errorf("regular file is not color terminal")
+	}
+
+	noColor, ok := os.LookupEnv("NO_COLOR")
+	os.Setenv("NO_COLOR", "1")
+	defer func() {
+		if ok {
+			os.Setenv("NO_COLOR", noColor)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+	if isColorTerminal(os.Stdout) {
+		t.Errorf("NO_COLOR should disable color")
+	}
+}
+
+func TestColoredMessage(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+
+	event := newTestEvent(LOG_WARN, Fields{"user": "bob"}, "disk %d%%", 90)
+	colored := event.getColoredMessage()
+	if !strings.Contains(colored, colorYellow+"WARN") || !strings.HasSuffix(colored, "disk 90% {user=bob}\n") {
+		t.Errorf("unexpected colored message %q", colored)
+	}
+	if strings.Contains(event.getMessage(), "\x1b[") {
+		t.Errorf("plain message should not have color : %q", event.getMessage())
+	}
+
+	errEvent := newTestEvent(LOG_ERROR, nil, "failed", errors.New("boom"))
+	colored = errEvent.getColoredMessage()
+	if !strings.Contains(colored, colorMagenta+"\tTRACE <<<"+colorReset) {
+		t.Errorf("trace block should be colored line by line : %q", colored)
+	}
+}
+
+func TestFileOutputHasNoColor(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	Warn("plain")
+
+	if content := readTestLog(t); strings.Contains(content, "\x1b[") {
+		t.Errorf("log file should not have color : %q", content)
+	}
+}
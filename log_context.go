@@ -0,0 +1,253 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 3:20
+//
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// well-known field names
+const (
+	FIELD_TRACE_ID = "trace_id"
+	FIELD_SPAN_ID  = "span_id"
+)
+
+// Fields is structured key/value data attached to log event
+type Fields map[string]interface{}
+
+// TraceExtractor pulls trace id and span id from context.
+// it returns empty strings when context has no span.
+type TraceExtractor func(ctx context.Context) (traceId string, spanId string)
+
+type contextKey int
+
+const (
+	contextKeyFields contextKey = iota
+	contextKeyLogger
+	contextKeySpan
+	contextKeyTags
+)
+
+type spanContext struct {
+	traceId string
+	spanId  string
+}
+
+var traceExtractors = []TraceExtractor{extractSpanContext}
+var traceExtractorMutex sync.RWMutex
+
+// AddTraceExtractor registers extractor for tracing library span context
+// e.g. OpenTelemetry :
+//   log.AddTraceExtractor(func(ctx context.Context) (string, string) {
+//       sc := trace.SpanContextFromContext(ctx)
+//       if !sc.IsValid() {
+//           return "", ""
+//       }
+//       return sc.TraceID().String(), sc.SpanID().String()
+//   })
+func AddTraceExtractor(extractor TraceExtractor) {
+	if extractor == nil {
+		return
+	}
+
+	traceExtractorMutex.Lock()
+	defer traceExtractorMutex.Unlock()
+	traceExtractors = append(traceExtractors, extractor)
+}
+
+// WithSpan returns context carrying trace id and span id
+func WithSpan(ctx context.Context, traceId string, spanId string) context.Context {
+	return context.WithValue(ctx, contextKeySpan, spanContext{traceId: traceId, spanId: spanId})
+}
+
+func extractSpanContext(ctx context.Context) (string, string) {
+	if sc, ok := ctx.Value(contextKeySpan).(spanContext); ok {
+		return sc.traceId, sc.spanId
+	}
+	return "", ""
+}
+
+// WithFields returns context carrying fields. fields of parent context are kept
+func WithFields(ctx context.Context, fields Fields) context.Context {
+	merged := make(Fields)
+	if parent, ok := ctx.Value(contextKeyFields).(Fields); ok {
+		for k, v := range parent {
+			merged[k] = v
+		}
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, contextKeyFields, merged)
+}
+
+// FieldsFromContext returns fields attached to context including trace/span id
+func FieldsFromContext(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+
+	traceExtractorMutex.RLock()
+	extractors := traceExtractors
+	traceExtractorMutex.RUnlock()
+
+	var fields Fields
+	for _, extractor := range extractors {
+		traceId, spanId := extractor(ctx)
+		if len(traceId) == 0 {
+			continue
+		}
+		fields = Fields{FIELD_TRACE_ID: traceId}
+		if len(spanId) > 0 {
+			fields[FIELD_SPAN_ID] = spanId
+		}
+		break
+	}
+
+	if attached, ok := ctx.Value(contextKeyFields).(Fields); ok {
+		if fields == nil {
+			fields = make(Fields)
+		}
+		for k, v := range attached {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// WithTags returns context carrying sentry tags which override global tags
+func WithTags(ctx context.Context, tags map[string]string) context.Context {
+	merged := make(map[string]string)
+	if parent, ok := ctx.Value(contextKeyTags).(map[string]string); ok {
+		for k, v := range parent {
+			merged[k] = v
+		}
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, contextKeyTags, merged)
+}
+
+// TagsFromContext returns sentry tags attached to context
+func TagsFromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+
+	tags, _ := ctx.Value(contextKeyTags).(map[string]string)
+	return tags
+}
+
+// WithLogger returns context carrying logger
+func WithLogger(ctx context.Context, logger customLogger) context.Context {
+	return context.WithValue(ctx, contextKeyLogger, logger)
+}
+
+// FromContext returns logger attached to context (or INFO logger) with context fields and tags
+func FromContext(ctx context.Context) customLogger {
+	if ctx == nil {
+		return customLogger{level: LOG_INFO}
+	}
+
+	logger, ok := ctx.Value(contextKeyLogger).(customLogger)
+	if !ok {
+		logger = customLogger{level: LOG_INFO}
+	}
+	return logger.WithFields(FieldsFromContext(ctx)).WithTags(TagsFromContext(ctx))
+}
+
+func ErrorContext(ctx context.Context, v ...interface{}) {
+	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_ERROR && len(v) > 0 {
+		print(2, LOG_ERROR, FieldsFromContext(ctx), TagsFromContext(ctx), v...)
+	}
+}
+
+func WarnContext(ctx context.Context, v ...interface{}) {
+	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_WARN && len(v) > 0 {
+		print(2, LOG_WARN, FieldsFromContext(ctx), TagsFromContext(ctx), v...)
+	}
+}
+
+func InfoContext(ctx context.Context, v ...interface{}) {
+	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_INFO && len(v) > 0 {
+		print(2, LOG_INFO, FieldsFromContext(ctx), TagsFromContext(ctx), v...)
+	}
+}
+
+func DebugContext(ctx context.Context, v ...interface{}) {
+	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_DEBUG && len(v) > 0 {
+		print(2, LOG_DEBUG, FieldsFromContext(ctx), TagsFromContext(ctx), v...)
+	}
+}
+
+func TraceContext(ctx context.Context, v ...interface{}) {
+	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_TRACE && len(v) > 0 {
+		print(2, LOG_TRACE, FieldsFromContext(ctx), TagsFromContext(ctx), v...)
+	}
+}
+
+func sortedFieldKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func buildFieldsDescription(fields Fields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(" {")
+	for i, k := range sortedFieldKeys(fields) {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		description := fmt.Sprintf("%s=%v", k, fields[k])
+		// fields keep their values for sinks. only text line is escaped
+		if logPreference.Sanitize {
+			description = sanitizeText(description)
+		}
+		buffer.WriteString(description)
+	}
+	buffer.WriteByte('}')
+	return buffer.String()
+}
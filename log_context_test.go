@@ -0,0 +1,152 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 3:20
+//
+
+package log
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestWithFieldsMergesParentFields(t *testing.T) {
+	ctx := WithFields(context.Background(), Fields{"user": "bob", "region": "kr"})
+	ctx = WithFields(ctx, Fields{"user": "alice"})
+
+	fields := FieldsFromContext(ctx)
+	if len(fields) != 2 || fields["user"] != "alice" || fields["region"] != "kr" {
+		t.Errorf("unexpected fields %v", fields)
+	}
+	if fields := FieldsFromContext(context.Background()); fields != nil {
+		t.Errorf("context without fields should have nil fields, got %v", fields)
+	}
+}
+
+func TestWithTagsMergesParentTags(t *testing.T) {
+	ctx := WithTags(context.Background(), map[string]string{"zone": "a", "tenant": "t1"})
+	ctx = WithTags(ctx, map[string]string{"zone": "b"})
+
+	tags := TagsFromContext(ctx)
+	if len(tags) != 2 || tags["zone"] != "b" || tags["tenant"] != "t1" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	if TagsFromContext(context.Background()) != nil {
+		t.Errorf("context without tags should return nil")
+	}
+}
+
+func TestFieldsFromContextExtractsSpan(t *testing.T) {
+	ctx := WithSpan(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+	fields := FieldsFromContext(WithFields(ctx, Fields{"user": "bob"}))
+	if fields[FIELD_TRACE_ID] != "4bf92f3577b34da6a3ce929d0e0e4736" || fields[FIELD_SPAN_ID] != "00f067aa0ba902b7" || fields["user"] != "bob" {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
+
+type testTraceKey struct{}
+
+func TestAddTraceExtractor(t *testing.T) {
+	registered := traceExtractors
+	t.Cleanup(func() {
+		traceExtractors = registered
+	})
+
+	AddTraceExtractor(func(ctx context.Context) (string, string) {
+		if id, ok := ctx.Value(testTraceKey{}).(string); ok {
+			return id, ""
+		}
+		return "", ""
+	})
+	AddTraceExtractor(nil)
+
+	fields := FieldsFromContext(context.WithValue(context.Background(), testTraceKey{}, "abc"))
+	if fields[FIELD_TRACE_ID] != "abc" {
+		t.Errorf("extractor is not applied : %v", fields)
+	}
+	if _, ok := fields[FIELD_SPAN_ID]; ok {
+		t.Errorf("empty span id should not be field : %v", fields)
+	}
+}
+
+func TestAddTraceExtractorWhileLogging(t *testing.T) {
+	registered := traceExtractors
+	t.Cleanup(func() {
+		traceExtractors = registered
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			AddTraceExtractor(func(ctx context.Context) (string, string) { return "", "" })
+		}
+	}()
+	ctx := WithSpan(context.Background(), "trace-1", "span-1")
+	for i := 0; i < 100; i++ {
+		if fields := FieldsFromContext(ctx); fields[FIELD_TRACE_ID] != "trace-1" {
+			t.Fatalf("unexpected fields %v", fields)
+		}
+	}
+	<-done
+}
+
+func TestFromContextWithNilContext(t *testing.T) {
+	logger := FromContext(nil)
+	if logger.level != LOG_INFO || len(logger.fields) != 0 {
+		t.Errorf("nil context should return INFO logger without fields")
+	}
+}
+
+func TestContextLoggingWritesFields(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+
+	ctx := WithSpan(context.Background(), "trace-1", "span-1")
+	ctx = WithFields(ctx, Fields{"user": "bob"})
+	InfoContext(ctx, "login %s", "ok")
+	FromContext(ctx).Printf("custom %d", 1)
+	FromContext(WithLogger(ctx, NewCustomLogger("debug").WithFields(Fields{"component": "auth"}))).Printf("debug line")
+
+	content := readTestLog(t)
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", content)
+	}
+	if !strings.HasSuffix(lines[0], "login ok {span_id=span-1, trace_id=trace-1, user=bob}") {
+		t.Errorf("unexpected line %q", lines[0])
+	}
+	if !strings.Contains(lines[1], " INFO ") || !strings.HasSuffix(lines[1], "custom 1 {span_id=span-1, trace_id=trace-1, user=bob}") {
+		t.Errorf("unexpected line %q", lines[1])
+	}
+	if !strings.Contains(lines[2], " DEBUG ") || !strings.HasSuffix(lines[2], "debug line {component=auth, span_id=span-1, trace_id=trace-1, user=bob}") {
+		t.Errorf("unexpected line %q", lines[2])
+	}
+}
+
+func TestBuildFieldsDescription(t *testing.T) {
+	if description := buildFieldsDescription(nil); description != "" {
+		t.Errorf("empty fields should have no description, got %q", description)
+	}
+	if description := buildFieldsDescription(Fields{"b": 2, "a": "x"}); description != " {a=x, b=2}" {
+		t.Errorf("fields should be sorted by key, got %q", description)
+	}
+}
@@ -56,6 +56,12 @@ const (
 	DEFAULT_SOURCE_PRINT_SIZE = 30
 	DEFAULT_ERROR_TRACE_LEVEL = 10
 	DEFAULT_SENTRY_FLUSH_SECOND = 2
+	DEFAULT_REPORTER_FLUSH_TIMEOUT = time.Second * 2
+	DEFAULT_SENTRY_MAX_BREADCRUMBS = 30
+	DEFAULT_SENTRY_QUEUE_SIZE = 256
+	DEFAULT_SENTRY_BREADCRUMB_BUFFER_SIZE = 256
+	DEFAULT_SENTRY_FINGERPRINT_LIMIT = 10
+	DEFAULT_SENTRY_EVENTS_PER_MINUTE = 100
 )
 
 // logging preference delivery mode
@@ -69,16 +75,69 @@ type LogDeliveryMode uint8
 const (
 	STREAM_MODE_STDOUT = 1 << iota
 	STREAM_MODE_FILE
+	STREAM_MODE_TEE = STREAM_MODE_STDOUT | STREAM_MODE_FILE
 )
 
 type LogStreamMode uint8
 
+// output format of log file and console
+const (
+	OUTPUT_FORMAT_TEXT = 1 << iota
+	OUTPUT_FORMAT_JSON
+)
+
+type LogOutputFormat uint8
+
+// json schema profiles
+const (
+	JSON_PROFILE_DEFAULT = 1 << iota
+	JSON_PROFILE_GCP	// google cloud logging
+	JSON_PROFILE_DATADOG
+	JSON_PROFILE_ECS	// elastic common schema
+)
+
+type JsonProfile uint8
+
 // log event
 type LogEvent interface {
-	getTime() time.Time
+	// Time returns time when event occurred
+	Time() time.Time
+	// Level returns event level
+	Level() LogLevel
+	// Message returns formatted message without time, level and source
+	Message() string
+	// File returns source file path of logging point
+	File() string
+	// Line returns source line of logging point
+	Line() int
+	// Function returns package qualified function name of logging point
+	Function() string
+	// Fields returns structured fields
+	Fields() Fields
+	// Tags returns sentry tags which override global tags
+	Tags() map[string]string
+	// Err returns error value of event. nil if event has no error
+	Err() error
+	// Trace returns trace points of callers. nil if event has no error
+	Trace() []TracePoint
+	// Recovered returns recovered panic value. nil if event is not panic
+	Recovered() interface{}
+	// SetLevel changes event level
+	SetLevel(level LogLevel)
+	// Args returns format and arguments of event
+	Args() []interface{}
+	// SetArgs replaces format and arguments of event
+	SetArgs(args ...interface{})
+	// SetField adds structured field
+	SetField(key string, value interface{})
+	// Clone returns copy of event
+	Clone() LogEvent
 	getMessage() string
+	getColoredMessage() string
 	setLevel(level LogLevel)
 	setArgs(args ...interface{})
+	setFields(fields Fields)
+	setTags(tags map[string]string)
 	publish()
 }
 
@@ -169,20 +228,35 @@ type preference struct {
 	streamMode         LogStreamMode
 	ShowMethod         bool
 	KeepingFileDays    uint16
+	ErrorLogFile       bool
+	ErrorKeepingFileDays uint16
 	SourcePrintSize    uint8
 	LogfileSizeLimitMB uint16
 	MaxErrorTraceLevel uint8
+	RethrowPanic       bool
 	ProcessName        string
 	sentryDsn 		   string
 	sentryTag 		   map[string]string
 	sentryFlushSecond  uint8
 	sentryLogLevel     LogLevel
+	sentryBreadcrumbLevel LogLevel
+	sentryMaxBreadcrumbs  int
+	sentryFingerprintLimit int
+	sentryEventsPerMinute  int
 	DefaultLogLevel    LogLevel
 	DeliveryMode       LogDeliveryMode
-	logFileLoaded      bool
-	logFilePath        string
-	currentLogFileTime time.Time
-	logFilePtr         *os.File
+	TeeConsole         bool
+	ConsoleLogLevel    LogLevel
+	ConsoleStderr      bool
+	consoleColor       bool
+	OutputFormat       LogOutputFormat
+	JsonProfile        JsonProfile
+	GcpProjectId       string
+	Redaction          bool
+	Sanitize           bool
+	MaxMessageLength   int
+	SinkOnly           bool
+	ReporterFlushTimeout time.Duration
 }
 
 
@@ -217,11 +291,20 @@ func createDefaultPreference(logFolder string)	preference	{
 	pref.ShowMethod = true
 	pref.DefaultLogLevel = LOG_TRACE
 	pref.DeliveryMode = DELIVERY_MODE_SYNC
+	pref.ConsoleLogLevel = LOG_TRACE
+	pref.OutputFormat = OUTPUT_FORMAT_TEXT
+	pref.JsonProfile = JSON_PROFILE_DEFAULT
 	pref.KeepingFileDays = DEFAULT_KEEPING_FILE_DAYS
+	pref.ErrorKeepingFileDays = DEFAULT_KEEPING_FILE_DAYS
 	pref.SourcePrintSize = DEFAULT_SOURCE_PRINT_SIZE
 	pref.MaxErrorTraceLevel = DEFAULT_ERROR_TRACE_LEVEL
+	pref.ReporterFlushTimeout = DEFAULT_REPORTER_FLUSH_TIMEOUT
 	pref.sentryFlushSecond = DEFAULT_SENTRY_FLUSH_SECOND
 	pref.sentryLogLevel = LOG_ERROR
+	pref.sentryBreadcrumbLevel = LOG_DEBUG
+	pref.sentryMaxBreadcrumbs = DEFAULT_SENTRY_MAX_BREADCRUMBS
+	pref.sentryFingerprintLimit = DEFAULT_SENTRY_FINGERPRINT_LIMIT
+	pref.sentryEventsPerMinute = DEFAULT_SENTRY_EVENTS_PER_MINUTE
 
 	return pref
 }
@@ -230,12 +313,31 @@ func normalizePreference(pref *preference) {
 	if pref.KeepingFileDays < 1 {
 		pref.KeepingFileDays = DEFAULT_KEEPING_FILE_DAYS
 	}
+	if pref.ErrorKeepingFileDays < 1 {
+		pref.ErrorKeepingFileDays = pref.KeepingFileDays
+	}
 	if pref.SourcePrintSize < 1 {
 		pref.SourcePrintSize = DEFAULT_SOURCE_PRINT_SIZE
 	}
 	if pref.MaxErrorTraceLevel < 3 {
 		pref.MaxErrorTraceLevel = DEFAULT_ERROR_TRACE_LEVEL
 	}
+	if pref.OutputFormat == 0 {
+		pref.OutputFormat = OUTPUT_FORMAT_TEXT
+	}
+	if pref.JsonProfile == 0 {
+		pref.JsonProfile = JSON_PROFILE_DEFAULT
+	}
+	if pref.ReporterFlushTimeout <= 0 {
+		pref.ReporterFlushTimeout = DEFAULT_REPORTER_FLUSH_TIMEOUT
+	}
+	// events go to sinks and reporters only. e.g. test capture
+	if pref.SinkOnly {
+		pref.streamMode = 0
+	}
+	if pref.TeeConsole && pref.streamMode == STREAM_MODE_FILE {
+		pref.streamMode = STREAM_MODE_TEE
+	}
 }
 
 func getProgramName() string {
@@ -53,11 +53,26 @@ type TracePoint struct {
 	line int
 }
 
+// Function returns package qualified function name of trace point
+func (point TracePoint) Function() string {
+	return findFullFunctionName(point.pc)
+}
+
+func (point TracePoint) File() string {
+	return point.file
+}
+
+func (point TracePoint) Line() int {
+	return point.line
+}
+
 type ErrorTraceLogEvent struct {
 	GeneralLogEvent
 	announce   bool
 	originError	error
+	recovered  interface{}
 	tracePoint []TracePoint
+	trace      string
 }
 
 func (event *ErrorTraceLogEvent) append(point TracePoint) {
@@ -67,31 +82,79 @@ func (event *ErrorTraceLogEvent) append(point TracePoint) {
 func (event *ErrorTraceLogEvent) publish() {
 	var buffer bytes.Buffer
 
-	codeLine := event.buildMessage(func() string {
-		size := len(event.message)
-		if size == 1 {
-			event.announce = true
-			return fmt.Sprintf("(%s) :: %s", reflect.TypeOf(event.message[0]).String(), event.message[0])
-		} else {
-			if format, ok := event.message[0].(string); ok {
-				if size == 2 {
-					return format
-				} else {
-					return fmt.Sprintf(format, event.message[1:size-1]...)
-				}
-			}
-			event.announce = true
-			return fmt.Sprintf("(%s) :: %s", reflect.TypeOf(event.message[size-1]).String(), event.message[size-1])
-		}
-	})
+	codeLine := event.buildMessage(event.formatArgs)
 
+	if isRedactionEnabled() {
+		event.originError = redactError(event.originError)
+	}
+	event.trace = event.getTrace()
 	buffer.WriteString(codeLine)
-	buffer.WriteString(event.getTrace())
+	buffer.WriteString(event.trace)
 	event.published = buffer.String()
+}
+
+func (event *ErrorTraceLogEvent) formatArgs() string {
+	// args may be replaced by hook after Message() was called
+	event.announce = false
+	size := len(event.message)
+	if size == 1 {
+		event.announce = true
+		return fmt.Sprintf("(%s) :: %s", reflect.TypeOf(event.message[0]).String(), event.message[0])
+	} else {
+		if format, ok := event.message[0].(string); ok {
+			if size == 2 {
+				return format
+			} else {
+				return fmt.Sprintf(format, event.message[1:size-1]...)
+			}
+		}
+		event.announce = true
+		return fmt.Sprintf("(%s) :: %s", reflect.TypeOf(event.message[size-1]).String(), event.message[size-1])
+	}
+}
 
-	if event.originError != nil {
-		sentrySendException(event.level, event.originError)
+func (event *ErrorTraceLogEvent) Message() string {
+	// hook sees message before event is published
+	if len(event.published) == 0 {
+		return event.formatArgs()
 	}
+	return event.express
+}
+
+// SetArgs replaces format and arguments of event. error is kept as last argument
+func (event *ErrorTraceLogEvent) SetArgs(args ...interface{}) {
+	if len(args) == 0 {
+		return
+	}
+	if err, ok := args[len(args)-1].(error); ok {
+		event.originError = err
+	} else {
+		args = append(args, event.originError)
+	}
+	event.setArgs(args...)
+}
+
+func (event *ErrorTraceLogEvent) Clone() LogEvent {
+	clone := *event
+	clone.message = append([]interface{}(nil), event.message...)
+	clone.tracePoint = append([]TracePoint(nil), event.tracePoint...)
+	return &clone
+}
+
+func (event *ErrorTraceLogEvent) Err() error {
+	return event.originError
+}
+
+func (event *ErrorTraceLogEvent) Trace() []TracePoint {
+	return event.tracePoint
+}
+
+func (event *ErrorTraceLogEvent) Recovered() interface{} {
+	return event.recovered
+}
+
+func (event *ErrorTraceLogEvent) getColoredMessage() string {
+	return event.GeneralLogEvent.getColoredMessage() + colorize(colorMagenta, event.trace)
 }
 
 func (event *ErrorTraceLogEvent) getTrace() string {
@@ -101,7 +164,12 @@ func (event *ErrorTraceLogEvent) getTrace() string {
 		buffer.WriteString("\tTRACE <<<\n")
 	} else {
 		err := event.message[len(event.message)-1]
-		buffer.WriteString(fmt.Sprintf("\t(%s) :: %s\n\tTRACE <<<\n", reflect.TypeOf(err).String(), err))
+		text := event.originError.Error()
+		// trace block is multi-line on purpose. only error text is sanitized
+		if logPreference.Sanitize {
+			text = sanitizeText(text)
+		}
+		buffer.WriteString(fmt.Sprintf("\t(%s) :: %s\n\tTRACE <<<\n", reflect.TypeOf(err).String(), text))
 	}
 	for _, v := range event.tracePoint {
 		buffer.WriteString(fmt.Sprintf("\t[%s(), %s:%d]\n", findFunctionName(v.pc), buildSourcePath(v.file), v.line))
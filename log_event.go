@@ -52,6 +52,11 @@ type GeneralLogEvent struct {
 	funcName  string
 	line      int
 	message   []interface{}
+	fields    Fields
+	tags      map[string]string
+	source    string
+	express   string
+	text      string
 	published string
 }
 
@@ -60,18 +65,14 @@ func (this *GeneralLogEvent) getMessage() string {
 }
 
 func (this *GeneralLogEvent) publish() {
-	var express string
+	this.published = this.buildMessage(this.formatArgs)
+}
+
+func (this *GeneralLogEvent) formatArgs() string {
 	if format, ok := this.message[0].(string); ok {
-		express = fmt.Sprintf(format, this.message[1:]...)
-	} else {
-		express = fmt.Sprintf("%v", this.message[0])
+		return fmt.Sprintf(format, this.message[1:]...)
 	}
-
-	this.published = this.buildMessage(func() string {
-		return express
-	})
-
-	sentrySendMessage(this.level, express)
+	return fmt.Sprintf("%v", this.message[0])
 }
 
 func (this *GeneralLogEvent) buildMessage(f func() string) string {
@@ -102,11 +103,32 @@ func (this *GeneralLogEvent) buildMessage(f func() string) string {
 		}
 	}
 
+	this.source = this.buildSourceDescription(buffer.String())
+	this.express = f()
+	if isRedactionEnabled() {
+		this.express = redactText(this.express)
+		this.fields = redactFields(this.fields)
+		this.tags = redactTags(this.tags)
+	}
+	if logPreference.Sanitize {
+		this.express = sanitizeText(this.express)
+	}
+	this.express = truncateText(this.express, logPreference.MaxMessageLength)
+	this.text = this.express + buildFieldsDescription(this.fields)
 	return fmt.Sprintf("%s %s [%s] %s\n",
 		this.t.Format("2006-01-02 15:04:05.000"),
 		this.levelStr,
-		this.buildSourceDescription(buffer.String()),
-		f())
+		this.source,
+		this.text)
+}
+
+// getColoredMessage returns published message with ANSI colors for console
+func (this *GeneralLogEvent) getColoredMessage() string {
+	return fmt.Sprintf("%s %s %s %s\n",
+		colorize(colorDim, this.t.Format("2006-01-02 15:04:05.000")),
+		colorize(levelColor(this.level), this.levelStr),
+		colorize(colorDim, "["+this.source+"]"),
+		this.text)
 }
 
 func (this *GeneralLogEvent) buildSourceDescription(source string) string {
@@ -131,10 +153,54 @@ func (this *GeneralLogEvent) buildSourceDescription(source string) string {
 	return buffer.String()
 }
 
-func (this *GeneralLogEvent) getTime() time.Time {
+func (this *GeneralLogEvent) Time() time.Time {
 	return this.t
 }
 
+func (this *GeneralLogEvent) Level() LogLevel {
+	return this.level
+}
+
+func (this *GeneralLogEvent) Message() string {
+	// hook sees message before event is published
+	if len(this.published) == 0 {
+		return this.formatArgs()
+	}
+	return this.express
+}
+
+func (this *GeneralLogEvent) File() string {
+	return this.file
+}
+
+func (this *GeneralLogEvent) Line() int {
+	return this.line
+}
+
+func (this *GeneralLogEvent) Function() string {
+	return findFullFunctionName(this.pc)
+}
+
+func (this *GeneralLogEvent) Fields() Fields {
+	return this.fields
+}
+
+func (this *GeneralLogEvent) Tags() map[string]string {
+	return this.tags
+}
+
+func (this *GeneralLogEvent) Err() error {
+	return nil
+}
+
+func (this *GeneralLogEvent) Trace() []TracePoint {
+	return nil
+}
+
+func (this *GeneralLogEvent) Recovered() interface{} {
+	return nil
+}
+
 func (this *GeneralLogEvent) setLevel(level LogLevel) {
 	this.level = level
 	switch level {
@@ -155,11 +221,57 @@ func (this *GeneralLogEvent) setArgs(args ...interface{}) {
 	this.message = args
 }
 
+func (this *GeneralLogEvent) setFields(fields Fields) {
+	this.fields = fields
+}
+
+func (this *GeneralLogEvent) setTags(tags map[string]string) {
+	this.tags = tags
+}
+
+// SetLevel changes event level. it is used by hook
+func (this *GeneralLogEvent) SetLevel(level LogLevel) {
+	this.setLevel(level)
+}
+
+// Args returns format and arguments of event
+func (this *GeneralLogEvent) Args() []interface{} {
+	return this.message
+}
+
+// SetArgs replaces format and arguments of event. empty args is ignored
+func (this *GeneralLogEvent) SetArgs(args ...interface{}) {
+	if len(args) > 0 {
+		this.setArgs(args...)
+	}
+}
+
+// SetField adds field to event. fields shared with context are not modified
+func (this *GeneralLogEvent) SetField(key string, value interface{}) {
+	fields := make(Fields, len(this.fields)+1)
+	for k, v := range this.fields {
+		fields[k] = v
+	}
+	fields[key] = value
+	this.fields = fields
+}
+
+// Clone returns copy of event which can be modified independently
+func (this *GeneralLogEvent) Clone() LogEvent {
+	clone := *this
+	clone.message = append([]interface{}(nil), this.message...)
+	return &clone
+}
+
 func findFunctionName(pc uintptr) string {
-	var funcName = runtime.FuncForPC(pc).Name()
+	var funcName = findFullFunctionName(pc)
 	var found = strings.LastIndexByte(funcName, '.')
 	if found < 0 {
 		return funcName
 	}
 	return funcName[found+1:]
 }
+
+func findFullFunctionName(pc uintptr) string {
+	return runtime.FuncForPC(pc).Name()
+}
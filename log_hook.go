@@ -0,0 +1,76 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. PM 7:00
+//
+
+package log
+
+import (
+	"sync"
+)
+
+// Hook runs on every event before it is written. event is not published yet, so hook can
+// change level, args and fields. hook returns events to be written :
+// nil to veto, []LogEvent{event} to keep, []LogEvent{event, event.Clone()} to duplicate
+type Hook func(event LogEvent) []LogEvent
+
+var hooks []Hook
+var hookMutex sync.RWMutex
+
+// AddHook registers hook. hooks run in registration order on caller goroutine
+func AddHook(hook Hook) {
+	if hook == nil {
+		return
+	}
+
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// ClearHooks unregisters every hook
+func ClearHooks() {
+	hookMutex.Lock()
+	defer hookMutex.Unlock()
+	hooks = nil
+}
+
+// applyHooks passes event through hooks and returns events to be written
+func applyHooks(event LogEvent) []LogEvent {
+	// hook may log or add hook. it is called without lock
+	hookMutex.RLock()
+	registered := hooks
+	hookMutex.RUnlock()
+
+	events := []LogEvent{event}
+	for _, hook := range registered {
+		next := make([]LogEvent, 0, len(events))
+		for _, e := range events {
+			for _, result := range hook(e) {
+				if result != nil {
+					next = append(next, result)
+				}
+			}
+		}
+		events = next
+	}
+	return events
+}
@@ -0,0 +1,228 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 1:30
+//
+
+package log
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// addTestHook registers hook during test
+func addTestHook(t *testing.T, hook Hook) {
+	AddHook(hook)
+	t.Cleanup(ClearHooks)
+}
+
+// logLines returns log lines without time and source prefix
+func logLines(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		if i := strings.Index(line, "] "); i >= 0 {
+			line = line[i+2:]
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestHookVeto(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	addTestHook(t, func(event LogEvent) []LogEvent {
+		if strings.Contains(event.Message(), "health") {
+			return nil
+		}
+		return []LogEvent{event}
+	})
+
+	Info("health check")
+	Info("request done")
+	waitTestLog(t, "request done")
+
+	if content := readTestLog(t); strings.Contains(content, "health check") {
+		t.Errorf("vetoed event should not be written\n%s", content)
+	}
+}
+
+func TestHookDuplicatesWithClone(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	addTestHook(t, func(event LogEvent) []LogEvent {
+		clone := event.Clone()
+		clone.SetField("copy", true)
+		clone.SetArgs("audit : %s", event.Message())
+		return []LogEvent{event, clone}
+	})
+
+	NewCustomLogger("info").WithFields(Fields{"user": "bob"}).Printf("login")
+	waitTestLog(t, "audit : login")
+
+	content := readTestLog(t)
+	if !strings.Contains(content, "login {user=bob}\n") || !strings.Contains(content, "audit : login {copy=true, user=bob}\n") {
+		t.Errorf("original and clone should be written independently\n%s", content)
+	}
+}
+
+func TestHookBumpsLevel(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.ErrorLogFile = true
+	pref.DefaultLogLevel = LOG_INFO
+	initTestLogger(t, pref)
+	addTestHook(t, func(event LogEvent) []LogEvent {
+		switch {
+		case strings.Contains(event.Message(), "timeout"):
+			event.SetLevel(LOG_WARN)
+		case strings.Contains(event.Message(), "noise"):
+			// lowered under logging level is dropped
+			event.SetLevel(LOG_DEBUG)
+		}
+		return []LogEvent{event}
+	})
+
+	Info("db timeout")
+	Info("noise")
+	Info("done")
+	waitTestLog(t, "done")
+
+	content := readTestLog(t)
+	if !strings.Contains(content, "WARN") || !strings.Contains(content, "db timeout") || strings.Contains(content, "noise") {
+		t.Errorf("unexpected log\n%s", content)
+	}
+	errorLog, _ := ioutil.ReadFile(filepath.Join(pref.logFolder, "test.error.log"))
+	if !strings.Contains(string(errorLog), "db timeout") {
+		t.Errorf("bumped event should be written to error log file\n%s", errorLog)
+	}
+}
+
+func TestHookSetArgsKeepsError(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	addTestHook(t, func(event LogEvent) []LogEvent {
+		event.SetArgs("masked %s", "query")
+		return []LogEvent{event}
+	})
+
+	Error("select * from secret", errors.New("syntax error"))
+	waitTestLog(t, "TRACE <<<")
+
+	content := readTestLog(t)
+	if !strings.Contains(content, "masked query\n") || !strings.Contains(content, ":: syntax error\n") || strings.Contains(content, "secret") {
+		t.Errorf("unexpected log\n%s", content)
+	}
+}
+
+func TestHookSameResultInSyncAndAsync(t *testing.T) {
+	hook := func(event LogEvent) []LogEvent {
+		if event.Message() == "drop" {
+			return nil
+		}
+		event.SetField("hooked", event.Level().String())
+		return []LogEvent{event, event.Clone()}
+	}
+
+	var results []string
+	for _, mode := range []LogDeliveryMode{DELIVERY_MODE_SYNC, DELIVERY_MODE_ASYNC} {
+		pref := newTestPreference(t)
+		pref.DeliveryMode = mode
+		initTestLogger(t, pref)
+		addTestHook(t, hook)
+
+		Info("first")
+		Info("drop")
+		Warn("second")
+		waitForLogEventDelivered()
+
+		var lines []string
+		for deadline := time.Now().Add(2 * time.Second); len(lines) < 4 && time.Now().Before(deadline); {
+			time.Sleep(10 * time.Millisecond)
+			content, _ := ioutil.ReadFile(filepath.Join(pref.logFolder, "test.log"))
+			lines = logLines(string(content))
+		}
+		// async consumer of previous logger may reorder lines
+		sort.Strings(lines)
+		results = append(results, strings.Join(lines, "\n"))
+		ClearHooks()
+	}
+
+	if results[0] != results[1] {
+		t.Errorf("sync and async results differ\nsync\n%s\nasync\n%s", results[0], results[1])
+	}
+	if strings.Count(results[0], "first {hooked=INFO}") != 2 || strings.Contains(results[0], "drop") {
+		t.Errorf("unexpected result\n%s", results[0])
+	}
+}
+
+func TestHookMayAddHookAndLog(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	added := false
+	addTestHook(t, func(event LogEvent) []LogEvent {
+		if !added {
+			added = true
+			AddHook(func(event LogEvent) []LogEvent {
+				event.SetField("second", true)
+				return []LogEvent{event}
+			})
+			Info("logged in hook")
+		}
+		return []LogEvent{event}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		Info("first")
+		Info("next")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("hook calling AddHook or logger should not deadlock")
+	}
+	waitTestLog(t, "next")
+
+	content := readTestLog(t)
+	if !strings.Contains(content, "logged in hook") || !strings.Contains(content, "second=true") {
+		t.Errorf("unexpected log\n%s", content)
+	}
+}
+
+func TestHookSetArgsAfterMessage(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	addTestHook(t, func(event LogEvent) []LogEvent {
+		if strings.Contains(event.Message(), "syntax error") {
+			event.SetArgs("query failed")
+		}
+		return []LogEvent{event}
+	})
+
+	Error(errors.New("syntax error"))
+	waitTestLog(t, "TRACE <<<")
+
+	content := readTestLog(t)
+	if !strings.Contains(content, "query failed\n") || !strings.Contains(content, ":: syntax error\n") {
+		t.Errorf("error text should be written after args are replaced\n%s", content)
+	}
+}
@@ -31,7 +31,7 @@ import (
 	"time"
 	"io/ioutil"
 	"regexp"
-	"strings"
+	"sync/atomic"
 )
 
 const (
@@ -39,113 +39,184 @@ const (
 	Hertz = 100	// general linux CLK_TCK
 )
 
+// rolling log file which is moved to backup every day
+type logFile struct {
+	name        string	// file name without extension. e.g. proc, proc.error
+	path        string
+	keepingDays *uint16
+	loaded      bool
+	currentTime time.Time
+	filePtr     *os.File
+}
+
+var mainLogFile logFile
+var errorLogFile logFile
+
+func newLogFile(name string, keepingDays *uint16) logFile {
+	file := logFile{}
+	file.name = name
+	file.path = fmt.Sprintf("%s.log", filepath.Join(logPreference.logFolder, name))
+	file.keepingDays = keepingDays
+	return file
+}
+
 func writeLogEvent(log LogEvent) {
 	log.publish()
-	if logPreference.streamMode == STREAM_MODE_STDOUT {
-		fmt.Printf("%s", log.getMessage())
+	countEvent(log.Level())
+	reportLogEvent(log)
+
+	// json output is one line per event
+	var message string
+	if logPreference.OutputFormat == OUTPUT_FORMAT_JSON {
+		message = string(encodeJSONProfile(log, logPreference.JsonProfile)) + "\n"
 	} else {
-		ensureLogFileExist()
-		ensureTodayLog(log.getTime())
-		writeLogEventToFile(log.getMessage())
+		message = log.getMessage()
+	}
+
+	if logPreference.streamMode&STREAM_MODE_FILE != 0 {
+		mainLogFile.write(log.Time(), message)
+		// error log file keeps only WARN, ERROR events
+		if logPreference.ErrorLogFile && log.Level() <= LOG_WARN {
+			errorLogFile.write(log.Time(), message)
+		}
 	}
+
+	switch logPreference.streamMode {
+	case STREAM_MODE_STDOUT:
+		writeLogEventToConsole(log, message)
+	case STREAM_MODE_TEE:
+		// console copy has its own level threshold
+		if logPreference.ConsoleLogLevel >= log.Level() {
+			writeLogEventToConsole(log, message)
+		}
+	}
+
+	writeLogEventToSinks(log)
 }
 
-func ensureTodayLog(t time.Time) {
-	if logPreference.currentLogFileTime.Year() != t.Year() ||
-		logPreference.currentLogFileTime.Month() != t.Month() ||
-		logPreference.currentLogFileTime.Day() != t.Day() {
-		moveToBackupLog()
+func getConsoleFile() *os.File {
+	if logPreference.ConsoleStderr {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+func writeLogEventToConsole(log LogEvent, message string) {
+	if logPreference.consoleColor && logPreference.OutputFormat == OUTPUT_FORMAT_TEXT {
+		countWrite(fmt.Fprintf(getConsoleFile(), "%s", log.getColoredMessage()))
+	} else {
+		countWrite(fmt.Fprintf(getConsoleFile(), "%s", message))
 	}
 }
 
-func ensureLogFileExist() {
-	if logPreference.logFileLoaded {
+func (file *logFile) write(t time.Time, message string) {
+	file.ensureExist()
+	file.ensureToday(t)
+	countWrite(file.writeString(message))
+}
+
+func (file *logFile) ensureToday(t time.Time) {
+	if file.currentTime.Year() != t.Year() ||
+		file.currentTime.Month() != t.Month() ||
+		file.currentTime.Day() != t.Day() {
+		file.moveToBackup()
+	}
+}
+
+func (file *logFile) ensureExist() {
+	if file.loaded {
 		return
 	}
 
 	var err error
 	var stat os.FileInfo
 
-	stat, err = os.Stat(logPreference.logFilePath)
+	stat, err = os.Stat(file.path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			logPreference.logFilePtr, err = os.Create(logPreference.logFilePath)
+			file.filePtr, err = os.Create(file.path)
 			if err != nil {
-				fmt.Printf("%s fail to create : %s", logPreference.logFilePath, err)
-				logPreference.logFilePtr = nil
+				atomic.AddUint64(&loggerStats.WriteErrors, 1)
+				fmt.Printf("%s fail to create : %s", file.path, err)
+				file.filePtr = nil
 				return
 			}
-			logPreference.currentLogFileTime = time.Now()
+			file.currentTime = time.Now()
 		} else if stat.IsDir() {
-			fmt.Printf("%s path exist as directory. fail to logging", logPreference.logFilePath)
-			logPreference.logFilePtr = nil
+			fmt.Printf("%s path exist as directory. fail to logging", file.path)
+			file.filePtr = nil
 		}
 	} else {
-		logPreference.logFilePtr, err = os.OpenFile(logPreference.logFilePath, os.O_APPEND|os.O_WRONLY, 0600)
+		file.filePtr, err = os.OpenFile(file.path, os.O_APPEND|os.O_WRONLY, 0600)
 		if err != nil {
+			atomic.AddUint64(&loggerStats.WriteErrors, 1)
 			fmt.Printf("fail to open : %s", err)
-			logPreference.logFilePtr = nil
+			file.filePtr = nil
 		}
-		logPreference.currentLogFileTime = stat.ModTime()
+		file.currentTime = stat.ModTime()
 	}
 
-	logPreference.logFileLoaded = true
+	file.loaded = true
 }
 
-func moveToBackupLog() {
+func (file *logFile) moveToBackup() {
 	var err error
 	var stat os.FileInfo
 
-	stat, err = os.Stat(logPreference.logFilePath)
+	stat, err = os.Stat(file.path)
 	if err != nil {
 		fmt.Printf("fail to stat log file : %s\n", err)
-		logPreference.logFilePtr = nil
+		file.filePtr = nil
 		return
 	}
 
 	// close current log file ptr
-	if logPreference.logFilePtr != nil {
-		logPreference.logFilePtr.Close()
-		logPreference.logFilePtr = nil
+	if file.filePtr != nil {
+		file.filePtr.Close()
+		file.filePtr = nil
 	}
 
 	// move current file to backup
 	backupFilePath := fmt.Sprintf("%s%c%s.%s.log",
 		logPreference.logFolder,
 		filepath.Separator,
-		logPreference.ProcessName, stat.ModTime().Format(TIME_YYYYMMDD))
-	err = os.Rename(logPreference.logFilePath, backupFilePath)
+		file.name, stat.ModTime().Format(TIME_YYYYMMDD))
+	err = os.Rename(file.path, backupFilePath)
 	if err != nil {
-		fmt.Printf("fail to rename [%s] -> [%s] : %s\n", logPreference.logFilePath, backupFilePath, err.Error())
+		fmt.Printf("fail to rename [%s] -> [%s] : %s\n", file.path, backupFilePath, err.Error())
+	} else {
+		atomic.AddUint64(&loggerStats.Rotations, 1)
 	}
 
 	go func() {
-		removeOldLogFiles()
+		file.removeOldFiles()
 	}()
 
 	// wait for file-io cache released : skip 1 tick
 	time.Sleep(time.Millisecond * time.Duration(1000 / Hertz))
 
 	// open for new log file
-	logPreference.logFilePtr, err = os.OpenFile(logPreference.logFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	file.filePtr, err = os.OpenFile(file.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
 	if err != nil {
+		atomic.AddUint64(&loggerStats.WriteErrors, 1)
 		fmt.Printf("fail to open for new log file : %s\n", err.Error())
-		logPreference.logFilePtr = nil
+		file.filePtr = nil
 		return
 	}
 
-	logPreference.currentLogFileTime = time.Now()
+	file.currentTime = time.Now()
 }
 
-func writeLogEventToFile(s string) (n int, err error) {
-	if logPreference.logFilePtr == nil {
-		return 0, nil
+func (file *logFile) writeString(s string) (n int, err error) {
+	if file.filePtr == nil {
+		return 0, fmt.Errorf("log file %s is not open", file.name)
 	}
-	return logPreference.logFilePtr.WriteString(s)
+	return file.filePtr.WriteString(s)
 }
 
-func removeOldLogFiles() {
-	if logPreference.KeepingFileDays < 1 {
+func (file *logFile) removeOldFiles() {
+	keepingDays := *file.keepingDays
+	if keepingDays < 1 {
 		return
 	}
 
@@ -155,37 +226,24 @@ func removeOldLogFiles() {
 		return
 	}
 
-	express := fmt.Sprintf("%s\\.[0-9]+-[0-9]+-[0-9]+\\.log", logPreference.ProcessName)
+	// e.g. proc.2017-03-08.log, proc.error.2017-03-08.log
+	express := fmt.Sprintf("^%s\\.([0-9]+-[0-9]+-[0-9]+)\\.log$", regexp.QuoteMeta(file.name))
 	var validLogFileId = regexp.MustCompile(express)
-	for _, file := range files {
-		if !strings.HasSuffix(file.Name(), "log") {
-			continue
-		}
-
-		if !validLogFileId.MatchString(file.Name()) {
-			continue
-		}
-
-		dotIndex := strings.Index(file.Name(), ".")
-		if dotIndex < 1 {
+	for _, f := range files {
+		matched := validLogFileId.FindStringSubmatch(f.Name())
+		if matched == nil {
 			continue
 		}
 
-		lastDotIndex := strings.LastIndex(file.Name(), ".")
-		if lastDotIndex <= dotIndex {
-			continue
-		}
-
-		createdDateExpression := file.Name()[dotIndex+1:lastDotIndex]
-		createdDate, err := time.ParseInLocation(TIME_YYYYMMDD, createdDateExpression, time.Local)
+		createdDate, err := time.ParseInLocation(TIME_YYYYMMDD, matched[1], time.Local)
 		if err != nil {
 			continue
 		}
 
-		diff := time.Duration(24 * logPreference.KeepingFileDays) * time.Hour
+		diff := time.Duration(24 * keepingDays) * time.Hour
 		deadline := time.Now().Add(-diff)
 		if createdDate.Before(deadline) {
-			os.Remove(filepath.Join(logPreference.logFolder, file.Name()))
+			os.Remove(filepath.Join(logPreference.logFolder, f.Name()))
 		}
 	}
-}
\ No newline at end of file
+}
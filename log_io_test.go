@@ -0,0 +1,183 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 4:40
+//
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// redirectConsole replaces stdout or stderr with temporary file and returns reader of its content
+func redirectConsole(t *testing.T, stderr bool) func() string {
+	t.Helper()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "console"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := &os.Stdout
+	if stderr {
+		target = &os.Stderr
+	}
+	origin := *target
+	*target = file
+	t.Cleanup(func() {
+		*target = origin
+		file.Close()
+	})
+
+	return func() string {
+		data, err := ioutil.ReadFile(file.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+}
+
+func TestTeeWritesFileAndConsole(t *testing.T) {
+	console := redirectConsole(t, false)
+	pref := newTestPreference(t)
+	pref.TeeConsole = true
+	pref.ConsoleLogLevel = LOG_WARN
+	initTestLogger(t, pref)
+
+	Error("error line")
+	Info("info line")
+
+	content := readTestLog(t)
+	if !strings.Contains(content, "error line") || !strings.Contains(content, "info line") {
+		t.Errorf("log file should have every event : %q", content)
+	}
+	if output := console(); !strings.Contains(output, "error line") || strings.Contains(output, "info line") {
+		t.Errorf("console should have events passing ConsoleLogLevel : %q", output)
+	}
+
+	SetConsoleLevel(LOG_INFO)
+	Info("second info")
+	if output := console(); !strings.Contains(output, "second info") {
+		t.Errorf("SetConsoleLevel is not applied : %q", output)
+	}
+}
+
+func TestErrorLogFileKeepsWarnAndError(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.ErrorLogFile = true
+	initTestLogger(t, pref)
+
+	Error("error line")
+	Warn("warn line")
+	Info("info line")
+
+	content := readTestLog(t)
+	for _, line := range []string{"error line", "warn line", "info line"} {
+		if !strings.Contains(content, line) {
+			t.Errorf("main log file should have %q : %q", line, content)
+		}
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(logPreference.logFolder, "test.error.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errorContent := string(data)
+	if !strings.Contains(errorContent, "error line") || !strings.Contains(errorContent, "warn line") || strings.Contains(errorContent, "info line") {
+		t.Errorf("error log file should have WARN and ERROR only : %q", errorContent)
+	}
+}
+
+func TestRemoveOldFilesKeepsRetentionPerFile(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.ErrorLogFile = true
+	pref.KeepingFileDays = 30
+	pref.ErrorKeepingFileDays = 2
+	initTestLogger(t, pref)
+
+	old := time.Now().AddDate(0, 0, -10).Format(TIME_YYYYMMDD)
+	names := []string{"test." + old + ".log", "test.error." + old + ".log", "test.error." + time.Now().Format(TIME_YYYYMMDD) + ".log"}
+	for _, name := range names {
+		if err := ioutil.WriteFile(filepath.Join(logPreference.logFolder, name), []byte("x"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mainLogFile.removeOldFiles()
+	errorLogFile.removeOldFiles()
+
+	for i, expected := range []bool{true, false, true} {
+		_, err := os.Stat(filepath.Join(logPreference.logFolder, names[i]))
+		if exist := err == nil; exist != expected {
+			t.Errorf("%s exist %v, expected %v", names[i], exist, expected)
+		}
+	}
+}
+
+func TestErrorKeepingFileDaysDefaultsToKeepingFileDays(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.KeepingFileDays = 10
+	pref.ErrorKeepingFileDays = 0
+	normalizePreference(&pref)
+	if pref.ErrorKeepingFileDays != 10 {
+		t.Errorf("expected 10 days, got %d", pref.ErrorKeepingFileDays)
+	}
+}
+
+func TestErrorKeepingFileDaysKeptWithoutKeepingFileDays(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.KeepingFileDays = 0
+	pref.ErrorKeepingFileDays = 90
+	normalizePreference(&pref)
+	if pref.KeepingFileDays != DEFAULT_KEEPING_FILE_DAYS || pref.ErrorKeepingFileDays != 90 {
+		t.Errorf("expected %d and 90 days, got %d and %d", DEFAULT_KEEPING_FILE_DAYS, pref.KeepingFileDays, pref.ErrorKeepingFileDays)
+	}
+}
+
+func TestConsoleStderr(t *testing.T) {
+	stdout := redirectConsole(t, false)
+	stderr := redirectConsole(t, true)
+	pref := NewPreferenceWithProcName("", "test")
+	pref.ConsoleStderr = true
+	initTestLogger(t, pref)
+
+	Info("to stderr")
+	if output := stderr(); !strings.Contains(output, "to stderr") {
+		t.Errorf("console output should go to stderr : %q", output)
+	}
+	if output := stdout(); len(output) > 0 {
+		t.Errorf("stdout should be empty : %q", output)
+	}
+}
+
+func TestTeeConsoleWithoutLogFolder(t *testing.T) {
+	pref := NewPreferenceWithProcName("", "test")
+	pref.TeeConsole = true
+	normalizePreference(&pref)
+	if pref.streamMode != STREAM_MODE_STDOUT {
+		t.Errorf("tee without log folder should write console only, got %d", pref.streamMode)
+	}
+}
@@ -0,0 +1,303 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 10:40
+//
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ECS_VERSION = "8.11.0"
+
+// jsonObject builds json object keeping insertion order of keys
+type jsonObject struct {
+	buffer bytes.Buffer
+	keys   map[string]bool
+}
+
+func newJsonObject() *jsonObject {
+	object := jsonObject{}
+	object.keys = make(map[string]bool)
+	object.buffer.WriteByte('{')
+	return &object
+}
+
+// add appends key and value. duplicated key is ignored
+func (object *jsonObject) add(key string, value interface{}) {
+	if object.keys[key] {
+		return
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded, _ = json.Marshal(fmt.Sprintf("%v", value))
+	}
+	object.addRaw(key, encoded)
+}
+
+func (object *jsonObject) addRaw(key string, encoded []byte) {
+	if object.keys[key] {
+		return
+	}
+
+	encodedKey, _ := json.Marshal(key)
+	if len(object.keys) > 0 {
+		object.buffer.WriteByte(',')
+	}
+	object.buffer.Write(encodedKey)
+	object.buffer.WriteByte(':')
+	object.buffer.Write(encoded)
+	object.keys[key] = true
+}
+
+func (object *jsonObject) bytes() []byte {
+	return append(object.buffer.Bytes(), '}')
+}
+
+// encodeJSON encodes event as one line json object (without newline).
+// fields are placed at top level after standard keys
+func encodeJSON(event LogEvent) []byte {
+	object := newJsonObject()
+	object.add("time", event.Time().Format(time.RFC3339Nano))
+	object.add("level", event.Level().String())
+	object.add("process", logPreference.ProcessName)
+	object.add("message", event.Message())
+	object.add("file", event.File())
+	object.add("line", event.Line())
+	object.add("function", event.Function())
+	if event.Err() != nil {
+		object.add("error", event.Err().Error())
+		object.add("error_type", errorTypeName(event.Err()))
+		object.add("trace", buildTraceLines(event.Trace()))
+	}
+
+	fields := event.Fields()
+	for _, k := range sortedFieldKeys(fields) {
+		object.add(k, fields[k])
+	}
+	return object.bytes()
+}
+
+// encodeJSONProfile encodes event with keys of json schema profile
+func encodeJSONProfile(event LogEvent, profile JsonProfile) []byte {
+	switch profile {
+	case JSON_PROFILE_GCP:
+		return encodeGCP(event)
+	case JSON_PROFILE_DATADOG:
+		return encodeDatadog(event)
+	case JSON_PROFILE_ECS:
+		return encodeECS(event)
+	}
+	return encodeJSON(event)
+}
+
+// encodeGCP encodes event as google cloud logging structured log.
+// trace_id, span_id fields are mapped to logging.googleapis.com/trace, spanId
+func encodeGCP(event LogEvent) []byte {
+	object := newJsonObject()
+	object.add("time", event.Time().Format(time.RFC3339Nano))
+	object.add("severity", toGCPSeverity(event.Level()))
+	object.add("message", event.Message())
+
+	location := newJsonObject()
+	location.add("file", event.File())
+	// line is int64 which is encoded as string in cloud logging api
+	location.add("line", strconv.Itoa(event.Line()))
+	location.add("function", event.Function())
+	object.addRaw("logging.googleapis.com/sourceLocation", location.bytes())
+
+	labels := newJsonObject()
+	labels.add("process", logPreference.ProcessName)
+	object.addRaw("logging.googleapis.com/labels", labels.bytes())
+
+	if event.Err() != nil {
+		object.add("error", event.Err().Error())
+		object.add("error_type", errorTypeName(event.Err()))
+		// error reporting picks up stack_trace
+		object.add("stack_trace", fmt.Sprintf("%s: %s\n%s",
+			errorTypeName(event.Err()), event.Err().Error(), strings.Join(buildTraceLines(event.Trace()), "\n")))
+	}
+
+	fields := event.Fields()
+	for _, k := range sortedFieldKeys(fields) {
+		switch k {
+		case FIELD_TRACE_ID:
+			object.add("logging.googleapis.com/trace", toGCPTrace(fields[k]))
+		case FIELD_SPAN_ID:
+			object.add("logging.googleapis.com/spanId", fields[k])
+		default:
+			object.add(k, fields[k])
+		}
+	}
+	return object.bytes()
+}
+
+// toGCPTrace returns trace resource name projects/<GcpProjectId>/traces/<trace id>
+// which cloud logging links to cloud trace. trace id is kept as it is without project id
+func toGCPTrace(traceId interface{}) interface{} {
+	s, ok := traceId.(string)
+	if !ok || len(logPreference.GcpProjectId) == 0 || strings.HasPrefix(s, "projects/") {
+		return traceId
+	}
+	return "projects/" + logPreference.GcpProjectId + "/traces/" + s
+}
+
+// toGCPSeverity returns cloud logging severity. TRACE has no severity and is written as DEBUG
+func toGCPSeverity(level LogLevel) string {
+	switch level {
+	case LOG_ERROR:
+		return "ERROR"
+	case LOG_WARN:
+		return "WARNING"
+	case LOG_INFO:
+		return "INFO"
+	}
+	return "DEBUG"
+}
+
+// encodeDatadog encodes event with datadog standard attributes (status, logger.*, error.*).
+// trace_id, span_id fields are mapped to dd.trace_id, dd.span_id
+func encodeDatadog(event LogEvent) []byte {
+	object := newJsonObject()
+	object.add("timestamp", event.Time().Format(time.RFC3339Nano))
+	object.add("status", strings.ToLower(event.Level().String()))
+	object.add("message", event.Message())
+	object.add("service", logPreference.ProcessName)
+
+	logger := newJsonObject()
+	logger.add("name", logPreference.ProcessName)
+	logger.add("method_name", event.Function())
+	logger.add("file_name", event.File())
+	logger.add("line", event.Line())
+	object.addRaw("logger", logger.bytes())
+
+	if event.Err() != nil {
+		errObject := newJsonObject()
+		errObject.add("kind", errorTypeName(event.Err()))
+		errObject.add("message", event.Err().Error())
+		errObject.add("stack", strings.Join(buildTraceLines(event.Trace()), "\n"))
+		object.addRaw("error", errObject.bytes())
+	}
+
+	fields := event.Fields()
+	dd := newJsonObject()
+	for _, k := range sortedFieldKeys(fields) {
+		switch k {
+		case FIELD_TRACE_ID, FIELD_SPAN_ID:
+			dd.add(k, toDatadogId(fields[k]))
+		default:
+			object.add(k, fields[k])
+		}
+	}
+	if len(dd.keys) > 0 {
+		object.addRaw("dd", dd.bytes())
+	}
+	return object.bytes()
+}
+
+// toDatadogId returns decimal of low 64 bits of hex trace id (128 bit) or span id (64 bit)
+// as datadog expects. other values are kept as they are
+func toDatadogId(id interface{}) interface{} {
+	s, ok := id.(string)
+	if !ok || (len(s) != 32 && len(s) != 16) {
+		return id
+	}
+	low, err := strconv.ParseUint(s[len(s)-16:], 16, 64)
+	if err != nil {
+		return id
+	}
+	if len(s) == 32 {
+		if _, err := strconv.ParseUint(s[:16], 16, 64); err != nil {
+			return id
+		}
+	}
+	return strconv.FormatUint(low, 10)
+}
+
+// encodeECS encodes event as Elastic Common Schema document.
+// trace_id, span_id fields are mapped to trace.id, span.id and other fields are placed at top level
+func encodeECS(event LogEvent) []byte {
+	object := newJsonObject()
+	object.add("@timestamp", event.Time().Format(time.RFC3339Nano))
+	object.add("message", event.Message())
+	object.addRaw("ecs", newJsonObjectOf("version", ECS_VERSION))
+
+	origin := newJsonObject()
+	file := newJsonObject()
+	file.add("name", event.File())
+	file.add("line", event.Line())
+	origin.addRaw("file", file.bytes())
+	origin.add("function", event.Function())
+	logObject := newJsonObject()
+	logObject.add("level", strings.ToLower(event.Level().String()))
+	logObject.addRaw("origin", origin.bytes())
+	object.addRaw("log", logObject.bytes())
+
+	process := newJsonObject()
+	process.add("name", logPreference.ProcessName)
+	process.add("pid", os.Getpid())
+	object.addRaw("process", process.bytes())
+
+	if event.Err() != nil {
+		errObject := newJsonObject()
+		errObject.add("message", event.Err().Error())
+		errObject.add("type", errorTypeName(event.Err()))
+		errObject.add("stack_trace", strings.Join(buildTraceLines(event.Trace()), "\n"))
+		object.addRaw("error", errObject.bytes())
+	}
+
+	fields := event.Fields()
+	for _, k := range sortedFieldKeys(fields) {
+		switch k {
+		case FIELD_TRACE_ID:
+			object.addRaw("trace", newJsonObjectOf("id", fields[k]))
+		case FIELD_SPAN_ID:
+			object.addRaw("span", newJsonObjectOf("id", fields[k]))
+		default:
+			object.add(k, fields[k])
+		}
+	}
+	return object.bytes()
+}
+
+// newJsonObjectOf returns encoded json object of single key
+func newJsonObjectOf(key string, value interface{}) []byte {
+	object := newJsonObject()
+	object.add(key, value)
+	return object.bytes()
+}
+
+// buildTraceLines returns trace points as "function (file:line)"
+func buildTraceLines(trace []TracePoint) []string {
+	lines := make([]string, 0, len(trace))
+	for _, point := range trace {
+		lines = append(lines, fmt.Sprintf("%s (%s:%d)", point.Function(), point.File(), point.Line()))
+	}
+	return lines
+}
@@ -0,0 +1,151 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 11:50
+//
+
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newGoldenEvent builds event with fixed time and source location
+func newGoldenEvent(level LogLevel, fields Fields, v ...interface{}) LogEvent {
+	event := newTestEvent(level, fields, v...)
+
+	general := event
+	if errEvent, ok := event.(*ErrorTraceLogEvent); ok {
+		for i := range errEvent.tracePoint {
+			errEvent.tracePoint[i].file = "/src/app/caller.go"
+			errEvent.tracePoint[i].line = 7
+		}
+		general = &errEvent.GeneralLogEvent
+	}
+	generalEvent := general.(*GeneralLogEvent)
+	generalEvent.t = time.Date(2026, 10, 19, 9, 30, 0, 123456000, time.UTC)
+	generalEvent.file = "/src/app/main.go"
+	generalEvent.line = 42
+	return event
+}
+
+func TestJSONProfileGolden(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	fields := Fields{FIELD_TRACE_ID: "4bf92f3577b34da6a3ce929d0e0e4736", FIELD_SPAN_ID: "00f067aa0ba902b7", "user": "bob"}
+	info := newGoldenEvent(LOG_INFO, fields, "hello")
+	failed := newGoldenEvent(LOG_ERROR, nil, "failed", errors.New("boom"))
+	pid := os.Getpid()
+
+	tests := []struct {
+		name     string
+		profile  JsonProfile
+		event    LogEvent
+		expected string
+	}{
+		{"default", JSON_PROFILE_DEFAULT, info,
+			`{"time":"2026-10-19T09:30:00.123456Z","level":"INFO","process":"test","message":"hello","file":"/src/app/main.go","line":42,"function":"throosea.com/log.newGoldenEvent","span_id":"00f067aa0ba902b7","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","user":"bob"}`},
+		{"default error", JSON_PROFILE_DEFAULT, failed,
+			`{"time":"2026-10-19T09:30:00.123456Z","level":"ERROR","process":"test","message":"failed","file":"/src/app/main.go","line":42,"function":"throosea.com/log.newGoldenEvent","error":"boom","error_type":"*errors.errorString","trace":["throosea.com/log.newGoldenEvent (/src/app/caller.go:7)"]}`},
+		{"gcp", JSON_PROFILE_GCP, info,
+			`{"time":"2026-10-19T09:30:00.123456Z","severity":"INFO","message":"hello","logging.googleapis.com/sourceLocation":{"file":"/src/app/main.go","line":"42","function":"throosea.com/log.newGoldenEvent"},"logging.googleapis.com/labels":{"process":"test"},"logging.googleapis.com/spanId":"00f067aa0ba902b7","logging.googleapis.com/trace":"4bf92f3577b34da6a3ce929d0e0e4736","user":"bob"}`},
+		{"gcp error", JSON_PROFILE_GCP, failed,
+			`{"time":"2026-10-19T09:30:00.123456Z","severity":"ERROR","message":"failed","logging.googleapis.com/sourceLocation":{"file":"/src/app/main.go","line":"42","function":"throosea.com/log.newGoldenEvent"},"logging.googleapis.com/labels":{"process":"test"},"error":"boom","error_type":"*errors.errorString","stack_trace":"*errors.errorString: boom\nthroosea.com/log.newGoldenEvent (/src/app/caller.go:7)"}`},
+		{"datadog", JSON_PROFILE_DATADOG, info,
+			`{"timestamp":"2026-10-19T09:30:00.123456Z","status":"info","message":"hello","service":"test","logger":{"name":"test","method_name":"throosea.com/log.newGoldenEvent","file_name":"/src/app/main.go","line":42},"user":"bob","dd":{"span_id":"67667974448284343","trace_id":"11803532876627986230"}}`},
+		{"datadog error", JSON_PROFILE_DATADOG, failed,
+			`{"timestamp":"2026-10-19T09:30:00.123456Z","status":"error","message":"failed","service":"test","logger":{"name":"test","method_name":"throosea.com/log.newGoldenEvent","file_name":"/src/app/main.go","line":42},"error":{"kind":"*errors.errorString","message":"boom","stack":"throosea.com/log.newGoldenEvent (/src/app/caller.go:7)"}}`},
+		{"ecs", JSON_PROFILE_ECS, info,
+			fmt.Sprintf(`{"@timestamp":"2026-10-19T09:30:00.123456Z","message":"hello","ecs":{"version":"8.11.0"},"log":{"level":"info","origin":{"file":{"name":"/src/app/main.go","line":42},"function":"throosea.com/log.newGoldenEvent"}},"process":{"name":"test","pid":%d},"span":{"id":"00f067aa0ba902b7"},"trace":{"id":"4bf92f3577b34da6a3ce929d0e0e4736"},"user":"bob"}`, pid)},
+		{"ecs error", JSON_PROFILE_ECS, failed,
+			fmt.Sprintf(`{"@timestamp":"2026-10-19T09:30:00.123456Z","message":"failed","ecs":{"version":"8.11.0"},"log":{"level":"error","origin":{"file":{"name":"/src/app/main.go","line":42},"function":"throosea.com/log.newGoldenEvent"}},"process":{"name":"test","pid":%d},"error":{"message":"boom","type":"*errors.errorString","stack_trace":"throosea.com/log.newGoldenEvent (/src/app/caller.go:7)"}}`, pid)},
+	}
+	for _, test := range tests {
+		if encoded := string(encodeJSONProfile(test.event, test.profile)); encoded != test.expected {
+			t.Errorf("%s\n got  %s\n want %s", test.name, encoded, test.expected)
+		}
+	}
+}
+
+func TestJSONProfileGCPTraceResourceName(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.GcpProjectId = "my-project"
+	initTestLogger(t, pref)
+	event := newGoldenEvent(LOG_INFO, Fields{FIELD_TRACE_ID: "4bf92f3577b34da6a3ce929d0e0e4736"}, "hello")
+
+	expected := `{"time":"2026-10-19T09:30:00.123456Z","severity":"INFO","message":"hello","logging.googleapis.com/sourceLocation":{"file":"/src/app/main.go","line":"42","function":"throosea.com/log.newGoldenEvent"},"logging.googleapis.com/labels":{"process":"test"},"logging.googleapis.com/trace":"projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736"}`
+	if encoded := string(encodeJSONProfile(event, JSON_PROFILE_GCP)); encoded != expected {
+		t.Errorf("gcp trace\n got  %s\n want %s", encoded, expected)
+	}
+}
+
+func TestJSONProfileSeverity(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+
+	tests := []struct {
+		level   LogLevel
+		gcp     string
+		datadog string
+		ecs     string
+	}{
+		{LOG_ERROR, `"severity":"ERROR"`, `"status":"error"`, `"level":"error"`},
+		{LOG_WARN, `"severity":"WARNING"`, `"status":"warn"`, `"level":"warn"`},
+		{LOG_INFO, `"severity":"INFO"`, `"status":"info"`, `"level":"info"`},
+		{LOG_DEBUG, `"severity":"DEBUG"`, `"status":"debug"`, `"level":"debug"`},
+		{LOG_TRACE, `"severity":"DEBUG"`, `"status":"trace"`, `"level":"trace"`},
+	}
+	for _, test := range tests {
+		event := newGoldenEvent(test.level, nil, "message")
+		if encoded := string(encodeGCP(event)); !strings.Contains(encoded, test.gcp) {
+			t.Errorf("%s : gcp %s", test.level, encoded)
+		}
+		if encoded := string(encodeDatadog(event)); !strings.Contains(encoded, test.datadog) {
+			t.Errorf("%s : datadog %s", test.level, encoded)
+		}
+		if encoded := string(encodeECS(event)); !strings.Contains(encoded, test.ecs) {
+			t.Errorf("%s : ecs %s", test.level, encoded)
+		}
+	}
+}
+
+func TestJSONOutputFormat(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.OutputFormat = OUTPUT_FORMAT_JSON
+	pref.JsonProfile = JSON_PROFILE_ECS
+	initTestLogger(t, pref)
+
+	Info("first")
+	Warn("second")
+	waitTestLog(t, "second")
+
+	lines := strings.Split(strings.TrimSuffix(readTestLog(t), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 json lines, got %q", lines)
+	}
+	for i, expected := range []string{`"message":"first"`, `"message":"second"`} {
+		if !strings.HasPrefix(lines[i], `{"@timestamp":"`) || !strings.Contains(lines[i], expected) {
+			t.Errorf("unexpected line %s", lines[i])
+		}
+	}
+}
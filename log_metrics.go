@@ -0,0 +1,115 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. PM 8:20
+//
+
+package log
+
+import (
+	"bytes"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const DEFAULT_EXPVAR_NAME = "log"
+
+var expvarName string
+var expvarMutex sync.Mutex
+
+// MetricsHandler returns http handler which exposes logger counters in prometheus text format
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write(buildPrometheusText(GetStats()))
+	})
+}
+
+// PublishExpvar publishes logger counters as expvar variable (shown in /debug/vars).
+// name is DEFAULT_EXPVAR_NAME when empty. counters are published only once :
+// later call with the same name does nothing and other name returns error
+func PublishExpvar(name string) error {
+	if len(name) == 0 {
+		name = DEFAULT_EXPVAR_NAME
+	}
+
+	expvarMutex.Lock()
+	defer expvarMutex.Unlock()
+	if len(expvarName) > 0 {
+		if expvarName == name {
+			return nil
+		}
+		return fmt.Errorf("logger counters are already published as %s", expvarName)
+	}
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %s is already published", name)
+	}
+
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return GetStats()
+	}))
+	expvarName = name
+	return nil
+}
+
+func buildPrometheusText(stats Stats) []byte {
+	var buffer bytes.Buffer
+	writeMetric := func(name string, kind string, help string, samples ...interface{}) {
+		buffer.WriteString(fmt.Sprintf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind))
+		// samples are pairs of label and value
+		for i := 0; i+1 < len(samples); i += 2 {
+			buffer.WriteString(fmt.Sprintf("%s%s %d\n", name, samples[i], samples[i+1]))
+		}
+	}
+
+	writeMetric("log_events_total", "counter", "Written log events by level.",
+		`{level="error"}`, stats.EventsError,
+		`{level="warn"}`, stats.EventsWarn,
+		`{level="info"}`, stats.EventsInfo,
+		`{level="debug"}`, stats.EventsDebug,
+		`{level="trace"}`, stats.EventsTrace)
+	writeMetric("log_bytes_written_total", "counter", "Bytes written to log files and console.",
+		"", stats.BytesWritten)
+	writeMetric("log_file_rotations_total", "counter", "Daily log file rotations.",
+		"", stats.Rotations)
+	writeMetric("log_write_errors_total", "counter", "Failed writes of log files and console.",
+		"", stats.WriteErrors)
+	writeMetric("log_async_queue_depth", "gauge", "Events waiting in async delivery queue.",
+		"", stats.AsyncQueueDepth)
+	writeMetric("log_delivery_dropped_total", "counter", "Events dropped by reporters and sinks.",
+		"", stats.DeliveryDropped)
+	writeMetric("log_delivery_failures_total", "counter", "Failed delivery attempts of reporters and sinks.",
+		"", stats.DeliveryFailures)
+	writeMetric("log_sentry_queued_total", "counter", "Sentry events queued for delivery.",
+		"", stats.SentryQueued)
+	writeMetric("log_sentry_sent_total", "counter", "Sentry events handed over to sentry sdk.",
+		"", stats.SentrySent)
+	writeMetric("log_sentry_failures_total", "counter", "Sentry events which sentry sdk did not accept.",
+		"", stats.SentryFailures)
+	writeMetric("log_sentry_dropped_total", "counter", "Sentry events dropped before delivery by reason.",
+		`{reason="queue_full"}`, stats.SentryDropped,
+		`{reason="rate_limited"}`, stats.SentryRateLimited,
+		`{reason="budget_exceeded"}`, stats.SentryBudgetExceeded)
+	writeMetric("log_sentry_breadcrumbs_dropped_total", "counter", "Sentry breadcrumbs dropped because breadcrumb buffer is full.",
+		"", stats.SentryBreadcrumbsDropped)
+	return buffer.Bytes()
+}
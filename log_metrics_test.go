@@ -0,0 +1,182 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 2:10
+//
+
+package log
+
+import (
+	"encoding/json"
+	"expvar"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// scrapeTestMetrics returns samples of metrics handler by metric name with labels
+func scrapeTestMetrics(t *testing.T) (string, map[string]uint64) {
+	t.Helper()
+
+	server := httptest.NewServer(MetricsHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %s", contentType)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	samples := make(map[string]uint64)
+	for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		value, err := strconv.ParseUint(line[i+1:], 10, 64)
+		if err != nil {
+			t.Fatalf("invalid sample %q", line)
+		}
+		samples[line[:i]] = value
+	}
+	return string(body), samples
+}
+
+func TestMetricsHandlerFormat(t *testing.T) {
+	body, samples := scrapeTestMetrics(t)
+
+	metrics := []struct {
+		name   string
+		kind   string
+		labels []string
+	}{
+		{"log_events_total", "counter", []string{`{level="error"}`, `{level="warn"}`, `{level="info"}`, `{level="debug"}`, `{level="trace"}`}},
+		{"log_bytes_written_total", "counter", []string{""}},
+		{"log_file_rotations_total", "counter", []string{""}},
+		{"log_write_errors_total", "counter", []string{""}},
+		{"log_async_queue_depth", "gauge", []string{""}},
+		{"log_delivery_dropped_total", "counter", []string{""}},
+		{"log_delivery_failures_total", "counter", []string{""}},
+		{"log_sentry_queued_total", "counter", []string{""}},
+		{"log_sentry_sent_total", "counter", []string{""}},
+		{"log_sentry_failures_total", "counter", []string{""}},
+		{"log_sentry_dropped_total", "counter", []string{`{reason="queue_full"}`, `{reason="rate_limited"}`, `{reason="budget_exceeded"}`}},
+		{"log_sentry_breadcrumbs_dropped_total", "counter", []string{""}},
+	}
+	for _, metric := range metrics {
+		help := regexp.MustCompile(`(?m)^# HELP ` + metric.name + ` \S.*\n# TYPE ` + metric.name + ` ` + metric.kind + `$`)
+		if !help.MatchString(body) {
+			t.Errorf("HELP and TYPE of %s %s are missing", metric.name, metric.kind)
+		}
+		for _, label := range metric.labels {
+			if _, ok := samples[metric.name+label]; !ok {
+				t.Errorf("sample %s%s is missing", metric.name, label)
+			}
+		}
+	}
+}
+
+func TestMetricsCountEvents(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	_, before := scrapeTestMetrics(t)
+
+	Error("failed")
+	Warn("warned")
+	Info("first")
+	Info("second")
+	Trace("traced")
+	waitTestLog(t, "traced")
+
+	_, after := scrapeTestMetrics(t)
+	expected := map[string]uint64{
+		`log_events_total{level="error"}`: 1,
+		`log_events_total{level="warn"}`:  1,
+		`log_events_total{level="info"}`:  2,
+		`log_events_total{level="debug"}`: 0,
+		`log_events_total{level="trace"}`: 1,
+		`log_write_errors_total`:          0,
+	}
+	for name, n := range expected {
+		if after[name]-before[name] != n {
+			t.Errorf("%s increased by %d, expected %d", name, after[name]-before[name], n)
+		}
+	}
+	if written := after["log_bytes_written_total"] - before["log_bytes_written_total"]; written != uint64(len(readTestLog(t))) {
+		t.Errorf("bytes written %d, log file has %d bytes", written, len(readTestLog(t)))
+	}
+}
+
+func TestMetricsCountSentryEvents(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	transport := installTestSentry(t, LOG_ERROR)
+	before := GetStats()
+
+	Error("failed")
+	transport.Events()
+
+	after := GetStats()
+	if after.SentryQueued-before.SentryQueued != 1 || after.SentrySent-before.SentrySent != 1 {
+		t.Errorf("expected 1 queued and sent sentry event, got %d, %d",
+			after.SentryQueued-before.SentryQueued, after.SentrySent-before.SentrySent)
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	if err := PublishExpvar(""); err != nil {
+		t.Fatalf("publish failed : %s", err)
+	}
+
+	variable := expvar.Get(DEFAULT_EXPVAR_NAME)
+	if variable == nil {
+		t.Fatalf("expvar %s is not published", DEFAULT_EXPVAR_NAME)
+	}
+	var stats Stats
+	if err := json.Unmarshal([]byte(variable.String()), &stats); err != nil {
+		t.Fatalf("invalid expvar %s : %s", variable.String(), err)
+	}
+
+	if err := PublishExpvar(DEFAULT_EXPVAR_NAME); err != nil {
+		t.Errorf("publishing same name again should be ignored : %s", err)
+	}
+	if err := PublishExpvar("other"); err == nil {
+		t.Errorf("publishing other name should fail")
+	}
+	if expvar.Get("other") != nil {
+		t.Errorf("other name should not be published")
+	}
+}
+
+func TestMetricsCountWriteToUnopenedFile(t *testing.T) {
+	file := &logFile{name: "closed"}
+	before := GetStats().WriteErrors
+
+	countWrite(file.writeString("lost\n"))
+	if delta := GetStats().WriteErrors - before; delta != 1 {
+		t.Errorf("write to unopened file should count 1 write error, got %d", delta)
+	}
+}
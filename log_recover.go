@@ -0,0 +1,96 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 2:10
+//
+
+package log
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Recover recovers panic and logs it with full goroutine stack.
+// it must be called directly by defer statement : defer log.Recover()
+func Recover() {
+	if r := recover(); r != nil {
+		handlePanic(r)
+	}
+}
+
+// Go runs f in new goroutine with panic recovery
+func Go(f func()) {
+	go func() {
+		defer Recover()
+		f()
+	}()
+}
+
+func handlePanic(r interface{}) {
+	if loggerStatus == LOGGING_STATUS_RUNNING && effectiveLogLevel >= LOG_ERROR {
+		deliver(newPanicLogEvent(r))
+		waitForLogEventDelivered()
+		flushReporters()
+	}
+
+	if logPreference.RethrowPanic {
+		panic(r)
+	}
+}
+
+func newPanicLogEvent(r interface{}) *ErrorTraceLogEvent {
+	originError, ok := r.(error)
+	if !ok {
+		originError = fmt.Errorf("%v", r)
+	}
+
+	// skip newPanicLogEvent(), handlePanic(), Recover() and runtime panic frames
+	i := 3
+	pc, file, line, _ := runtime.Caller(i)
+	for isRuntimeFrame(pc) {
+		i++
+		pc, file, line, _ = runtime.Caller(i)
+	}
+
+	event := newErrorTraceLogEvent(pc, file, line, originError)
+	event.recovered = r
+	// full goroutine stack. not limited by MaxErrorTraceLevel
+	for i++; ; i++ {
+		pc, file, line, exist := runtime.Caller(i)
+		if !exist {
+			break
+		}
+		event.append(TracePoint{pc: pc, file: file, line: line})
+	}
+
+	event.setLevel(LOG_ERROR)
+	event.setArgs("panic recovered : %v", r, originError)
+	return event
+}
+
+func isRuntimeFrame(pc uintptr) bool {
+	f := runtime.FuncForPC(pc)
+	if f == nil {
+		return false
+	}
+	return strings.HasPrefix(f.Name(), "runtime.") || strings.HasPrefix(f.Name(), "internal/runtime")
+}
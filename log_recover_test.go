@@ -0,0 +1,97 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. AM 7:30
+//
+
+package log
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func panicWith(v interface{}) {
+	panic(v)
+}
+
+func TestGoRecoversPanic(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+
+	Go(func() {
+		panicWith(errors.New("boom"))
+	})
+
+	content := waitTestLog(t, "panic recovered : boom")
+	if !strings.Contains(content, "panic recovered : boom") {
+		t.Fatalf("panic is not logged : %s", content)
+	}
+	if !strings.Contains(content, "TRACE <<<") || !strings.Contains(content, "log_recover_test.go") {
+		t.Errorf("goroutine stack is not logged : %s", content)
+	}
+	if strings.Contains(content, "runtime.gopanic") || strings.Contains(content, "log.Recover()") {
+		t.Errorf("recovery frames should be skipped : %s", content)
+	}
+}
+
+func TestRecoverRethrowsPanic(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.RethrowPanic = true
+	initTestLogger(t, pref)
+
+	var rethrown interface{}
+	func() {
+		defer func() {
+			rethrown = recover()
+		}()
+		defer Recover()
+		panicWith("fatal")
+	}()
+
+	if rethrown != "fatal" {
+		t.Errorf("panic should be rethrown, got %v", rethrown)
+	}
+	if content := readTestLog(t); !strings.Contains(content, "panic recovered : fatal") {
+		t.Errorf("panic is not logged before rethrow : %s", content)
+	}
+}
+
+func TestNewPanicLogEventPointsPanicLocation(t *testing.T) {
+	var event *ErrorTraceLogEvent
+	func() {
+		defer func() {
+			event = newPanicLogEventFromRecover(recover())
+		}()
+		panicWith("here")
+	}()
+
+	if !strings.HasSuffix(event.file, "log_recover_test.go") {
+		t.Errorf("event should point panic location, got %s:%d", event.file, event.line)
+	}
+	if event.originError == nil || event.originError.Error() != "here" {
+		t.Errorf("non error panic should be wrapped as error, got %v", event.originError)
+	}
+}
+
+// newPanicLogEventFromRecover has the same call depth as Recover()
+func newPanicLogEventFromRecover(r interface{}) *ErrorTraceLogEvent {
+	return newPanicLogEvent(r)
+}
@@ -0,0 +1,268 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. PM 3:10
+//
+
+package log
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const REDACTED = "***"
+
+// SecretValue is sensitive value which is always written as *** in message, fields and every output
+type SecretValue struct {
+	value interface{}
+}
+
+// Secret wraps sensitive value. e.g. log.Info("login %s / %s", user, log.Secret(password))
+func Secret(v interface{}) SecretValue {
+	return SecretValue{value: v}
+}
+
+func (secret SecretValue) String() string {
+	return REDACTED
+}
+
+func (secret SecretValue) GoString() string {
+	return REDACTED
+}
+
+func (secret SecretValue) Format(f fmt.State, verb rune) {
+	f.Write([]byte(REDACTED))
+}
+
+func (secret SecretValue) MarshalJSON() ([]byte, error) {
+	return []byte("\"" + REDACTED + "\""), nil
+}
+
+// Value returns wrapped value
+func (secret SecretValue) Value() interface{} {
+	return secret.value
+}
+
+// redactRule replaces matched text of message, error and field values
+type redactRule struct {
+	pattern *regexp.Regexp
+	replace func(matched string) string
+}
+
+var redactRules []redactRule
+var redactFieldNames = make(map[string]bool)
+var redactMutex sync.RWMutex
+
+var (
+	redactEmailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	redactBearerPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)
+	redactPanPattern    = regexp.MustCompile(`\b[0-9](?:[ \-]?[0-9]){12,18}\b`)
+)
+
+// default sensitive field names which are masked when Redaction is enabled
+var defaultRedactFieldNames = []string{
+	"password", "passwd", "secret", "token", "access_token", "refresh_token",
+	"authorization", "api_key", "apikey", "card_number", "pan",
+}
+
+// enableDefaultRedaction registers rules for email, bearer token and card number (PAN)
+// and masking of well known sensitive field names
+func enableDefaultRedaction() {
+	redactMutex.Lock()
+	defer redactMutex.Unlock()
+
+	redactRules = append(redactRules,
+		redactRule{pattern: redactEmailPattern, replace: func(string) string { return REDACTED }},
+		redactRule{pattern: redactBearerPattern, replace: func(string) string { return "Bearer " + REDACTED }},
+		redactRule{pattern: redactPanPattern, replace: redactPan},
+	)
+	for _, name := range defaultRedactFieldNames {
+		redactFieldNames[name] = true
+	}
+}
+
+// AddRedactRule registers regular expression. matched text is replaced with replacement
+// ($1 style group reference is allowed) in message, error and field values
+func AddRedactRule(expr string, replacement string) error {
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+
+	redactMutex.Lock()
+	defer redactMutex.Unlock()
+	redactRules = append(redactRules, redactRule{pattern: pattern, replace: func(matched string) string {
+		return pattern.ReplaceAllString(matched, replacement)
+	}})
+	return nil
+}
+
+// AddRedactFields registers field names (case insensitive) whose value is always written as ***
+func AddRedactFields(names ...string) {
+	redactMutex.Lock()
+	defer redactMutex.Unlock()
+	for _, name := range names {
+		redactFieldNames[strings.ToLower(name)] = true
+	}
+}
+
+func isRedactionEnabled() bool {
+	redactMutex.RLock()
+	defer redactMutex.RUnlock()
+	return len(redactRules) > 0 || len(redactFieldNames) > 0
+}
+
+// redactText applies every rule to text
+func redactText(text string) string {
+	redactMutex.RLock()
+	defer redactMutex.RUnlock()
+
+	for _, rule := range redactRules {
+		text = rule.pattern.ReplaceAllStringFunc(text, rule.replace)
+	}
+	return text
+}
+
+// redactFields returns copy of fields with masked values. original map is not modified
+func redactFields(fields Fields) Fields {
+	if len(fields) == 0 {
+		return fields
+	}
+
+	redactMutex.RLock()
+	masked := make(Fields, len(fields))
+	for k, v := range fields {
+		if redactFieldNames[strings.ToLower(k)] {
+			masked[k] = REDACTED
+		} else {
+			masked[k] = v
+		}
+	}
+	redactMutex.RUnlock()
+
+	for k, v := range masked {
+		switch value := v.(type) {
+		case SecretValue:
+			masked[k] = REDACTED
+		case string:
+			masked[k] = redactText(value)
+		case error:
+			masked[k] = redactError(value)
+		case fmt.Stringer:
+			// value keeps its type unless its text is redacted
+			if text := value.String(); redactText(text) != text {
+				masked[k] = redactText(text)
+			}
+		}
+	}
+	return masked
+}
+
+// redactTags returns copy of tags with masked values. original map is not modified
+func redactTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return tags
+	}
+
+	redactMutex.RLock()
+	masked := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if redactFieldNames[strings.ToLower(k)] {
+			masked[k] = REDACTED
+		} else {
+			masked[k] = v
+		}
+	}
+	redactMutex.RUnlock()
+
+	for k, v := range masked {
+		masked[k] = redactText(v)
+	}
+	return masked
+}
+
+// redactPan masks card number which passes luhn check leaving last 4 digits
+func redactPan(matched string) string {
+	digits := make([]byte, 0, len(matched))
+	for i := 0; i < len(matched); i++ {
+		if matched[i] >= '0' && matched[i] <= '9' {
+			digits = append(digits, matched[i])
+		}
+	}
+	if len(digits) < 13 || len(digits) > 19 || !isLuhnValid(digits) {
+		return matched
+	}
+	return strings.Repeat("*", len(digits)-4) + string(digits[len(digits)-4:])
+}
+
+func isLuhnValid(digits []byte) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// redactedError keeps original error (errors.Is, errors.As) while Error() returns redacted text
+type redactedError struct {
+	err  error
+	text string
+}
+
+func (e redactedError) Error() string {
+	return e.text
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactError returns error whose text is redacted. original error is returned when nothing is redacted
+func redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	text := redactText(err.Error())
+	if text == err.Error() {
+		return err
+	}
+	return redactedError{err: err, text: text}
+}
+
+// errorTypeName returns type name of original error
+func errorTypeName(err error) string {
+	if redacted, ok := err.(redactedError); ok {
+		err = redacted.err
+	}
+	return reflect.TypeOf(err).String()
+}
@@ -0,0 +1,140 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 7:20
+//
+
+package log
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Reporter reports log events to external service (e.g. sentry).
+// every published event is handed over to registered reporters and
+// each reporter decides which levels it reports. reporter should not block caller
+type Reporter interface {
+	// ReportMessage reports event without error value
+	ReportMessage(event LogEvent)
+	// ReportException reports event with error value. event.Trace() has collected trace points
+	ReportException(event LogEvent)
+	// Flush waits until reported events are delivered. returns false on timeout
+	Flush(timeout time.Duration) bool
+}
+
+// reporter which implements io.Closer (e.g. webhook reporter) is closed
+// when it is removed or logger is closed
+
+var reporters []Reporter
+var reporterMutex sync.RWMutex
+
+// AddReporter registers reporter
+func AddReporter(reporter Reporter) {
+	if reporter == nil {
+		return
+	}
+
+	reporterMutex.Lock()
+	defer reporterMutex.Unlock()
+	reporters = append(reporters, reporter)
+}
+
+// RemoveReporter unregisters reporter and closes it when it implements io.Closer
+func RemoveReporter(reporter Reporter) {
+	reporterMutex.Lock()
+	removed := false
+	for i, r := range reporters {
+		if r == reporter {
+			reporters = append(reporters[:i:i], reporters[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	reporterMutex.Unlock()
+
+	// closing may wait for delivery. logging goes on meanwhile
+	if closer, ok := reporter.(io.Closer); ok && removed {
+		closer.Close()
+	}
+}
+
+// replaceReporter swaps old reporter with new one keeping its order. new one is appended when old is not registered
+func replaceReporter(old Reporter, reporter Reporter) {
+	reporterMutex.Lock()
+	defer reporterMutex.Unlock()
+
+	for i, r := range reporters {
+		if r == old {
+			reporters[i] = reporter
+			return
+		}
+	}
+	reporters = append(reporters, reporter)
+}
+
+func reportLogEvent(event LogEvent) {
+	reporterMutex.RLock()
+	defer reporterMutex.RUnlock()
+
+	for _, reporter := range reporters {
+		if event.Err() != nil {
+			reporter.ReportException(event)
+		} else {
+			reporter.ReportMessage(event)
+		}
+	}
+}
+
+// flushReporters flushes every reporter within ReporterFlushTimeout
+func flushReporters() bool {
+	reporterMutex.RLock()
+	defer reporterMutex.RUnlock()
+
+	deadline := time.Now().Add(logPreference.ReporterFlushTimeout)
+	flushed := true
+	for _, reporter := range reporters {
+		if !reporter.Flush(time.Until(deadline)) {
+			flushed = false
+		}
+	}
+	return flushed
+}
+
+// closeReporters closes and unregisters reporters which implement io.Closer
+func closeReporters() {
+	reporterMutex.Lock()
+	var closers []io.Closer
+	kept := reporters[:0:0]
+	for _, reporter := range reporters {
+		if closer, ok := reporter.(io.Closer); ok {
+			closers = append(closers, closer)
+		} else {
+			kept = append(kept, reporter)
+		}
+	}
+	reporters = kept
+	reporterMutex.Unlock()
+
+	for _, closer := range closers {
+		closer.Close()
+	}
+}
@@ -0,0 +1,170 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 7:40
+//
+
+package log
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testReporter keeps reported events in memory
+type testReporter struct {
+	mutex      sync.Mutex
+	messages   []LogEvent
+	exceptions []LogEvent
+	flushed    []time.Duration
+}
+
+func (reporter *testReporter) ReportMessage(event LogEvent) {
+	reporter.mutex.Lock()
+	defer reporter.mutex.Unlock()
+	reporter.messages = append(reporter.messages, event)
+}
+
+func (reporter *testReporter) ReportException(event LogEvent) {
+	reporter.mutex.Lock()
+	defer reporter.mutex.Unlock()
+	reporter.exceptions = append(reporter.exceptions, event)
+}
+
+func (reporter *testReporter) Flush(timeout time.Duration) bool {
+	reporter.mutex.Lock()
+	defer reporter.mutex.Unlock()
+	reporter.flushed = append(reporter.flushed, timeout)
+	return true
+}
+
+func (reporter *testReporter) events() ([]LogEvent, []LogEvent) {
+	reporter.mutex.Lock()
+	defer reporter.mutex.Unlock()
+	return append([]LogEvent(nil), reporter.messages...), append([]LogEvent(nil), reporter.exceptions...)
+}
+
+func TestReporterReceivesEvents(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	reporter := &testReporter{}
+	AddReporter(reporter)
+	defer RemoveReporter(reporter)
+
+	Info("hello %s", "world")
+	Error("failed", errors.New("boom"))
+	waitForLogEventDelivered()
+
+	messages, exceptions := reporter.events()
+	if len(messages) != 1 || len(exceptions) != 1 {
+		t.Fatalf("expected 1 message and 1 exception, got %d, %d", len(messages), len(exceptions))
+	}
+
+	message := messages[0]
+	if message.Level() != LOG_INFO || message.Message() != "hello world" || message.Err() != nil {
+		t.Errorf("unexpected message event %s %q", message.Level(), message.Message())
+	}
+	if !strings.HasSuffix(message.File(), "log_reporter_test.go") {
+		t.Errorf("unexpected source file %s", message.File())
+	}
+
+	exception := exceptions[0]
+	if exception.Level() != LOG_ERROR || exception.Message() != "failed" || exception.Err().Error() != "boom" {
+		t.Errorf("unexpected exception event %s %q %v", exception.Level(), exception.Message(), exception.Err())
+	}
+	trace := exception.Trace()
+	if len(trace) == 0 {
+		t.Fatalf("exception event should have trace points")
+	}
+	if !strings.HasSuffix(exception.File(), "log_reporter_test.go") {
+		t.Errorf("unexpected source file %s", exception.File())
+	}
+	if trace[0].Function() != "testing.tRunner" {
+		t.Errorf("first trace point should be caller of logging function, got %s", trace[0].Function())
+	}
+}
+
+func TestRemoveReporter(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	reporter := &testReporter{}
+	AddReporter(reporter)
+	RemoveReporter(reporter)
+
+	Warn("ignored")
+	waitForLogEventDelivered()
+
+	if messages, exceptions := reporter.events(); len(messages) != 0 || len(exceptions) != 0 {
+		t.Errorf("removed reporter should not receive events")
+	}
+}
+
+func TestFlushReporters(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	reporter := &testReporter{}
+	AddReporter(reporter)
+	defer RemoveReporter(reporter)
+
+	if !flushReporters() {
+		t.Errorf("flush should succeed")
+	}
+	if len(reporter.flushed) != 1 || reporter.flushed[0] <= 0 {
+		t.Errorf("reporter should be flushed with positive timeout, got %v", reporter.flushed)
+	}
+}
+
+func TestFlushReportersUsesReporterFlushTimeout(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.ReporterFlushTimeout = 5 * time.Second
+	initTestLogger(t, pref)
+	SetSentryFlushSecond(1)
+	defer SetSentryFlushSecond(DEFAULT_SENTRY_FLUSH_SECOND)
+	reporter := &testReporter{}
+	AddReporter(reporter)
+	defer RemoveReporter(reporter)
+
+	flushReporters()
+	if len(reporter.flushed) != 1 || reporter.flushed[0] <= 4*time.Second || reporter.flushed[0] > 5*time.Second {
+		t.Errorf("reporter should be flushed within ReporterFlushTimeout, got %v", reporter.flushed)
+	}
+}
+
+func TestReplaceReporterKeepsOrder(t *testing.T) {
+	first, second, third := &testReporter{}, &testReporter{}, &testReporter{}
+	AddReporter(first)
+	AddReporter(second)
+	defer RemoveReporter(first)
+
+	replaceReporter(second, third)
+	defer RemoveReporter(third)
+
+	reporterMutex.RLock()
+	registered := append([]Reporter(nil), reporters...)
+	reporterMutex.RUnlock()
+	if len(registered) < 2 || registered[len(registered)-2] != first || registered[len(registered)-1] != third {
+		t.Errorf("replaced reporter should keep its position")
+	}
+	for _, r := range registered {
+		if r == second {
+			t.Errorf("old reporter should be unregistered")
+		}
+	}
+}
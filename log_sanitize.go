@@ -0,0 +1,85 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. PM 4:30
+//
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf8"
+)
+
+const TRUNCATION_MARKER = "...(truncated)"
+
+// sanitizeText escapes CR, LF and control characters (e.g. ANSI escape) so that
+// user input can not forge log lines. invalid UTF-8 byte is replaced with U+FFFD
+func sanitizeText(text string) string {
+	if isSafeText(text) {
+		return text
+	}
+
+	var buffer bytes.Buffer
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buffer.WriteRune(utf8.RuneError)
+		case r == '\n':
+			buffer.WriteString("\\n")
+		case r == '\r':
+			buffer.WriteString("\\r")
+		case r == '\t':
+			buffer.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			buffer.WriteString(fmt.Sprintf("\\x%02x", r))
+		case r >= 0x80 && r < 0xa0:
+			buffer.WriteString(fmt.Sprintf("\\u%04x", r))
+		default:
+			buffer.WriteRune(r)
+		}
+	}
+	return buffer.String()
+}
+
+func isSafeText(text string) bool {
+	for _, r := range text {
+		if (r < 0x20 && r != '\t') || (r >= 0x7f && r < 0xa0) || r == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
+
+// truncateText cuts text over max bytes at rune boundary and appends truncation marker
+func truncateText(text string, max int) string {
+	if max < 1 || len(text) <= max {
+		return text
+	}
+
+	end := max
+	for end > 0 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return text[:end] + TRUNCATION_MARKER
+}
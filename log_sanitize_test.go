@@ -0,0 +1,164 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 12:50
+//
+
+package log
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"plain", "hello world", "hello world"},
+		{"tab", "a\tb", "a\tb"},
+		{"hangul", "한글 메시지", "한글 메시지"},
+		{"lf", "user=bob\n2026-10-19 [ERROR] forged", "user=bob\\n2026-10-19 [ERROR] forged"},
+		{"crlf", "a\r\nb", "a\\r\\nb"},
+		{"ansi", "\x1b[31mred\x1b[0m", "\\x1b[31mred\\x1b[0m"},
+		{"nul and del", "a\x00b\x7f", "a\\x00b\\x7f"},
+		{"c1 control", "a\u0085b", "a\\u0085b"},
+		{"invalid utf8", "a\xffb\xc3", "a�b�"},
+	}
+	for _, test := range tests {
+		if sanitized := sanitizeText(test.text); sanitized != test.expected {
+			t.Errorf("%s : sanitizeText(%q) = %q, expected %q", test.name, test.text, sanitized, test.expected)
+		}
+	}
+}
+
+// testStringer renders multi-line text
+type testStringer struct{}
+
+func (testStringer) String() string {
+	return "first\nsecond"
+}
+
+func TestSanitizeFieldsDescription(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.Sanitize = true
+	initTestLogger(t, pref)
+
+	tests := []struct {
+		name     string
+		fields   Fields
+		expected string
+	}{
+		{"string", Fields{"user": "bob\r\n"}, " {user=bob\\r\\n}"},
+		{"key", Fields{"user\nname": "bob"}, " {user\\nname=bob}"},
+		{"number and bool", Fields{"count": 3, "ok": true}, " {count=3, ok=true}"},
+		{"error", Fields{"cause": errors.New("bad\nline")}, " {cause=bad\\nline}"},
+		{"stringer", Fields{"value": testStringer{}}, " {value=first\\nsecond}"},
+		{"ansi", Fields{"color": "\x1b[31m"}, " {color=\\x1b[31m}"},
+		{"invalid utf8", Fields{"raw": "a\xffb"}, " {raw=a\ufffdb}"},
+	}
+	for _, test := range tests {
+		if description := buildFieldsDescription(test.fields); description != test.expected {
+			t.Errorf("%s : expected %q, got %q", test.name, test.expected, description)
+		}
+	}
+}
+
+func TestSanitizeKeepsFieldValues(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.Sanitize = true
+	initTestLogger(t, pref)
+	reporter := &testReporter{}
+	AddReporter(reporter)
+	defer RemoveReporter(reporter)
+
+	cause := errors.New("bad\nline")
+	NewCustomLogger("info").WithFields(Fields{"cause": cause, "value": testStringer{}, "user": "bob\n"}).Printf("hello")
+	waitTestLog(t, "hello")
+
+	messages, _ := reporter.events()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(messages))
+	}
+	fields := messages[0].Fields()
+	if fields["cause"] != cause || fields["value"] != (testStringer{}) || fields["user"] != "bob\n" {
+		t.Errorf("fields should keep their values for sinks, got %v", fields)
+	}
+	if content := readTestLog(t); !strings.Contains(content, "hello {cause=bad\\nline, user=bob\\n, value=first\\nsecond}\n") {
+		t.Errorf("text line should be escaped\n%s", content)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		max      int
+		expected string
+	}{
+		{"unlimited", "hello", 0, "hello"},
+		{"short", "hello", 5, "hello"},
+		{"ascii", "hello world", 5, "hello" + TRUNCATION_MARKER},
+		// '한' is 3 bytes. cut at 4 bytes keeps only first rune
+		{"multibyte boundary", "한글", 4, "한" + TRUNCATION_MARKER},
+		{"inside first rune", "한글", 2, TRUNCATION_MARKER},
+		{"exact rune end", "한글", 3, "한" + TRUNCATION_MARKER},
+	}
+	for _, test := range tests {
+		if truncated := truncateText(test.text, test.max); truncated != test.expected {
+			t.Errorf("%s : truncateText(%q, %d) = %q, expected %q", test.name, test.text, test.max, truncated, test.expected)
+		}
+	}
+}
+
+func TestSanitizePreference(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.Sanitize = true
+	initTestLogger(t, pref)
+
+	Warn("login failed for %s", "bob\n2026-10-19 09:00:00 [INFO] admin logged in")
+	Error("failed", errors.New("bad\ninput"))
+	waitTestLog(t, "TRACE <<<")
+
+	content := readTestLog(t)
+	if !strings.Contains(content, "login failed for bob\\n2026-10-19 09:00:00 [INFO] admin logged in\n") {
+		t.Errorf("message should be one line\n%s", content)
+	}
+	// error text is escaped but trace block stays multi-line
+	if !strings.Contains(content, ") :: bad\\ninput\n\tTRACE <<<\n\t") {
+		t.Errorf("unexpected error trace\n%s", content)
+	}
+}
+
+func TestMaxMessageLength(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.MaxMessageLength = 10
+	initTestLogger(t, pref)
+
+	Info("%s", strings.Repeat("가", 10))
+	waitTestLog(t, TRUNCATION_MARKER)
+
+	if content := readTestLog(t); !strings.Contains(content, " 가가가"+TRUNCATION_MARKER+"\n") {
+		t.Errorf("message should be truncated at rune boundary\n%s", content)
+	}
+}
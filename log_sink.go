@@ -0,0 +1,86 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 9:00
+//
+
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Sink receives every published event in addition to log file or stdout.
+// Write is called on logging goroutine (or async delivery goroutine),
+// so sink which talks to remote service should not block
+type Sink interface {
+	Write(event LogEvent) error
+	Close() error
+}
+
+var sinks []Sink
+var sinkMutex sync.RWMutex
+
+// AddSink registers sink
+func AddSink(sink Sink) {
+	if sink == nil {
+		return
+	}
+
+	sinkMutex.Lock()
+	defer sinkMutex.Unlock()
+	sinks = append(sinks, sink)
+}
+
+// RemoveSink unregisters sink. sink is not closed
+func RemoveSink(sink Sink) {
+	sinkMutex.Lock()
+	defer sinkMutex.Unlock()
+
+	for i, s := range sinks {
+		if s == sink {
+			sinks = append(sinks[:i:i], sinks[i+1:]...)
+			return
+		}
+	}
+}
+
+func writeLogEventToSinks(event LogEvent) {
+	sinkMutex.RLock()
+	defer sinkMutex.RUnlock()
+
+	for _, sink := range sinks {
+		if err := sink.Write(event); err != nil {
+			atomic.AddUint64(&loggerStats.DeliveryFailures, 1)
+		}
+	}
+}
+
+// closeSinks closes and unregisters every sink
+func closeSinks() {
+	sinkMutex.Lock()
+	defer sinkMutex.Unlock()
+
+	for _, sink := range sinks {
+		sink.Close()
+	}
+	sinks = nil
+}
@@ -0,0 +1,102 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 5:30
+//
+
+package log
+
+import (
+	"sync/atomic"
+)
+
+// Stats is snapshot of logger internal counters
+type Stats struct {
+	EventsError              uint64 // written ERROR events
+	EventsWarn               uint64 // written WARN events
+	EventsInfo               uint64 // written INFO events
+	EventsDebug              uint64 // written DEBUG events
+	EventsTrace              uint64 // written TRACE events
+	BytesWritten             uint64 // bytes written to log files and console
+	Rotations                uint64 // daily log file rotations
+	WriteErrors              uint64 // failed writes (or open) of log files and console
+	AsyncQueueDepth          uint64 // events waiting in async delivery queue (gauge)
+	SentryQueued             uint64 // sentry events queued for delivery
+	SentrySent               uint64 // sentry events handed over to sentry sdk
+	SentryFailures           uint64 // sentry events which sentry sdk did not accept
+	SentryDropped            uint64 // sentry events dropped because queue is full
+	SentryRateLimited        uint64 // sentry events dropped by per-fingerprint rate limit
+	SentryBudgetExceeded     uint64 // sentry events dropped by events-per-minute budget
+	SentryBreadcrumbsDropped uint64 // sentry breadcrumbs dropped because breadcrumb buffer is full
+	DeliveryDropped          uint64 // events dropped by reporters and sinks (queue full or delivery failure)
+	DeliveryFailures         uint64 // failed delivery attempts of reporters and sinks
+}
+
+var loggerStats Stats
+
+// GetStats returns snapshot of logger internal counters
+func GetStats() Stats {
+	return Stats{
+		EventsError:              atomic.LoadUint64(&loggerStats.EventsError),
+		EventsWarn:               atomic.LoadUint64(&loggerStats.EventsWarn),
+		EventsInfo:               atomic.LoadUint64(&loggerStats.EventsInfo),
+		EventsDebug:              atomic.LoadUint64(&loggerStats.EventsDebug),
+		EventsTrace:              atomic.LoadUint64(&loggerStats.EventsTrace),
+		BytesWritten:             atomic.LoadUint64(&loggerStats.BytesWritten),
+		Rotations:                atomic.LoadUint64(&loggerStats.Rotations),
+		WriteErrors:              atomic.LoadUint64(&loggerStats.WriteErrors),
+		AsyncQueueDepth:          uint64(len(logEventChannel)),
+		SentryQueued:             atomic.LoadUint64(&loggerStats.SentryQueued),
+		SentrySent:               atomic.LoadUint64(&loggerStats.SentrySent),
+		SentryFailures:           atomic.LoadUint64(&loggerStats.SentryFailures),
+		SentryDropped:            atomic.LoadUint64(&loggerStats.SentryDropped),
+		SentryRateLimited:        atomic.LoadUint64(&loggerStats.SentryRateLimited),
+		SentryBudgetExceeded:     atomic.LoadUint64(&loggerStats.SentryBudgetExceeded),
+		SentryBreadcrumbsDropped: atomic.LoadUint64(&loggerStats.SentryBreadcrumbsDropped),
+		DeliveryDropped:          atomic.LoadUint64(&loggerStats.DeliveryDropped),
+		DeliveryFailures:         atomic.LoadUint64(&loggerStats.DeliveryFailures),
+	}
+}
+
+// countEvent increases written event counter of level
+func countEvent(level LogLevel) {
+	switch level {
+	case LOG_ERROR:
+		atomic.AddUint64(&loggerStats.EventsError, 1)
+	case LOG_WARN:
+		atomic.AddUint64(&loggerStats.EventsWarn, 1)
+	case LOG_INFO:
+		atomic.AddUint64(&loggerStats.EventsInfo, 1)
+	case LOG_DEBUG:
+		atomic.AddUint64(&loggerStats.EventsDebug, 1)
+	case LOG_TRACE:
+		atomic.AddUint64(&loggerStats.EventsTrace, 1)
+	}
+}
+
+// countWrite records result of file or console write
+func countWrite(n int, err error) {
+	if n > 0 {
+		atomic.AddUint64(&loggerStats.BytesWritten, uint64(n))
+	}
+	if err != nil {
+		atomic.AddUint64(&loggerStats.WriteErrors, 1)
+	}
+}
@@ -0,0 +1,100 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. AM 7:30
+//
+
+package log
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// initTestLogger restarts logger with preference writing to temporary log folder.
+// logger is initialized only once per process otherwise
+func initTestLogger(t *testing.T, pref preference) {
+	t.Helper()
+
+	loggerStatus = LOGGING_STATUS_NOT_STARTED
+	Initialize(pref)
+	t.Cleanup(func() {
+		for _, file := range []*logFile{&mainLogFile, &errorLogFile} {
+			if file.filePtr != nil {
+				file.filePtr.Close()
+			}
+			*file = logFile{}
+		}
+		loggerStatus = LOGGING_STATUS_NOT_STARTED
+	})
+}
+
+func newTestPreference(t *testing.T) preference {
+	return NewPreferenceWithProcName(t.TempDir(), "test")
+}
+
+// readTestLog returns content of log file
+func readTestLog(t *testing.T) string {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filepath.Join(logPreference.logFolder, logPreference.ProcessName+".log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// waitTestLog waits until log file contains substring
+func waitTestLog(t *testing.T, substring string) string {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second * 2)
+	for {
+		data, _ := ioutil.ReadFile(filepath.Join(logPreference.logFolder, logPreference.ProcessName+".log"))
+		if strings.Contains(string(data), substring) || time.Now().After(deadline) {
+			return string(data)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+// newTestEvent builds published event as print does. last error argument makes error trace event
+func newTestEvent(level LogLevel, fields Fields, v ...interface{}) LogEvent {
+	pc, file, line, _ := runtime.Caller(1)
+
+	var event LogEvent
+	if originError, ok := v[len(v)-1].(error); ok {
+		errEvent := newErrorTraceLogEvent(pc, file, line, originError)
+		errEvent.append(TracePoint{pc: pc, file: file, line: line})
+		event = errEvent
+	} else {
+		event = newGeneralLogEvent(pc, file, line)
+	}
+
+	event.setLevel(level)
+	event.setArgs(v...)
+	event.setFields(fields)
+	event.publish()
+	return event
+}
@@ -0,0 +1,223 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. PM 5:40
+//
+
+// Package logtest captures events of throosea.com/log in tests.
+//
+//	func TestLogin(t *testing.T) {
+//		logtest.Capture(t)
+//		login("bob")
+//		logtest.RequireLogged(t, log.LOG_INFO, "login bob")
+//		logtest.AssertNoErrors(t)
+//	}
+package logtest
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"throosea.com/log"
+)
+
+// Event is structured copy of recorded log event
+type Event struct {
+	Time     time.Time
+	Level    log.LogLevel
+	Message  string
+	Fields   log.Fields
+	File     string
+	Line     int
+	Function string
+	Err      error
+}
+
+// Source returns "file.go:line"
+func (event Event) Source() string {
+	return fmt.Sprintf("%s:%d", filepath.Base(event.File), event.Line)
+}
+
+func (event Event) String() string {
+	var buffer strings.Builder
+	buffer.WriteString(fmt.Sprintf("%s [%s] %s", event.Level.String(), event.Source(), event.Message))
+	if event.Err != nil {
+		buffer.WriteString(" : ")
+		buffer.WriteString(event.Err.Error())
+	}
+	if len(event.Fields) > 0 {
+		buffer.WriteString(fmt.Sprintf(" %v", map[string]interface{}(event.Fields)))
+	}
+	return buffer.String()
+}
+
+// Recorder is sink which records events in memory
+type Recorder struct {
+	mutex  sync.Mutex
+	events []Event
+	tb     testing.TB
+}
+
+// NewRecorder creates recorder. register it with log.AddSink
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Write records event and forwards it to testing.TB.Log when recorder is created by Capture
+func (recorder *Recorder) Write(event log.LogEvent) error {
+	fields := make(log.Fields, len(event.Fields()))
+	for k, v := range event.Fields() {
+		fields[k] = v
+	}
+	recorded := Event{
+		Time:     event.Time(),
+		Level:    event.Level(),
+		Message:  event.Message(),
+		Fields:   fields,
+		File:     event.File(),
+		Line:     event.Line(),
+		Function: event.Function(),
+		Err:      event.Err(),
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.events = append(recorder.events, recorded)
+	if recorder.tb != nil {
+		recorder.tb.Log(recorded.String())
+	}
+	return nil
+}
+
+func (recorder *Recorder) Close() error {
+	return nil
+}
+
+// Events returns copy of recorded events
+func (recorder *Recorder) Events() []Event {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]Event(nil), recorder.events...)
+}
+
+// Reset removes recorded events
+func (recorder *Recorder) Reset() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.events = nil
+}
+
+// Filter returns recorded events of level
+func (recorder *Recorder) Filter(level log.LogLevel) []Event {
+	filtered := make([]Event, 0)
+	for _, event := range recorder.Events() {
+		if event.Level == level {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// Contains returns whether event of level whose message or error contains substring is recorded
+func (recorder *Recorder) Contains(level log.LogLevel, substring string) bool {
+	for _, event := range recorder.Filter(level) {
+		if strings.Contains(event.Message, substring) {
+			return true
+		}
+		if event.Err != nil && strings.Contains(event.Err.Error(), substring) {
+			return true
+		}
+	}
+	return false
+}
+
+var recorders = make(map[testing.TB]*Recorder)
+var recorderMutex sync.Mutex
+
+// Capture records events while test is running and forwards them to tb.Log,
+// so logs show up only for failing tests (or with -v).
+// logger is initialized to write to sinks only if it is not initialized yet.
+// logger is global, so only one test can capture at a time. Capture stops test
+// when another test is capturing (e.g. t.Parallel). calling it again in the same test returns the same recorder
+func Capture(tb testing.TB) *Recorder {
+	tb.Helper()
+
+	recorderMutex.Lock()
+	if recorder, ok := recorders[tb]; ok {
+		recorderMutex.Unlock()
+		return recorder
+	}
+	if len(recorders) > 0 {
+		recorderMutex.Unlock()
+		tb.Fatal("logtest.Capture is active in another test. do not capture in parallel tests")
+		return nil
+	}
+	recorder := NewRecorder()
+	recorder.tb = tb
+	recorders[tb] = recorder
+	recorderMutex.Unlock()
+
+	pref := log.NewPreference("")
+	pref.SinkOnly = true
+	log.Initialize(pref)
+	log.AddSink(recorder)
+
+	tb.Cleanup(func() {
+		log.RemoveSink(recorder)
+		recorderMutex.Lock()
+		delete(recorders, tb)
+		recorderMutex.Unlock()
+	})
+	return recorder
+}
+
+func getRecorder(tb testing.TB) *Recorder {
+	tb.Helper()
+
+	recorderMutex.Lock()
+	recorder, ok := recorders[tb]
+	recorderMutex.Unlock()
+	if !ok {
+		tb.Fatal("logtest.Capture(t) is not called")
+	}
+	return recorder
+}
+
+// RequireLogged stops test when event of level containing substring is not logged
+func RequireLogged(tb testing.TB, level log.LogLevel, substring string) {
+	tb.Helper()
+
+	if !getRecorder(tb).Contains(level, substring) {
+		tb.Fatalf("%s event containing %q is not logged", level.String(), substring)
+	}
+}
+
+// AssertNoErrors marks test failed when any ERROR event is logged
+func AssertNoErrors(tb testing.TB) {
+	tb.Helper()
+
+	for _, event := range getRecorder(tb).Filter(log.LOG_ERROR) {
+		tb.Errorf("unexpected error logged : %s", event.String())
+	}
+}
@@ -0,0 +1,185 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 11:30
+//
+
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"throosea.com/log"
+)
+
+// fakeTB records what logtest reports instead of failing the real test
+type fakeTB struct {
+	testing.TB
+	mutex    sync.Mutex
+	logs     []string
+	errors   []string
+	fatals   []string
+	cleanups []func()
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Log(args ...interface{}) {
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+	tb.logs = append(tb.logs, fmt.Sprint(args...))
+}
+
+func (tb *fakeTB) Errorf(format string, args ...interface{}) {
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Fatal(args ...interface{}) {
+	tb.mutex.Lock()
+	tb.fatals = append(tb.fatals, fmt.Sprint(args...))
+	tb.mutex.Unlock()
+	runtime.Goexit()
+}
+
+func (tb *fakeTB) Fatalf(format string, args ...interface{}) {
+	tb.Fatal(fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Cleanup(f func()) {
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+func (tb *fakeTB) cleanup() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+	tb.cleanups = nil
+}
+
+// run calls f on its own goroutine so that Fatal stops only f
+func (tb *fakeTB) run(f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	<-done
+}
+
+func TestCaptureForwardsEventsToTB(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	recorder := Capture(tb)
+	log.Info("hello %s", "bob")
+	log.WarnContext(log.WithFields(context.Background(), log.Fields{"user": "bob"}), "slow login")
+	tb.cleanup()
+	log.Info("after cleanup")
+
+	events := recorder.Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Level != log.LOG_INFO || events[0].Message != "hello bob" || events[0].Source() != fmt.Sprintf("logtest_test.go:%d", events[0].Line) {
+		t.Errorf("unexpected event %s", events[0].String())
+	}
+	if events[1].Fields["user"] != "bob" {
+		t.Errorf("fields are not recorded : %v", events[1].Fields)
+	}
+
+	if len(tb.logs) != 2 {
+		t.Fatalf("expected 2 forwarded logs, got %v", tb.logs)
+	}
+	if !strings.HasPrefix(tb.logs[0], "INFO [logtest_test.go:") || !strings.HasSuffix(tb.logs[0], "] hello bob") {
+		t.Errorf("unexpected forwarded log %q", tb.logs[0])
+	}
+	if !strings.Contains(tb.logs[1], "slow login map[user:bob]") {
+		t.Errorf("unexpected forwarded log %q", tb.logs[1])
+	}
+}
+
+func TestRequireLogged(t *testing.T) {
+	Capture(t)
+	log.Warn("disk usage %d%%", 90)
+	log.Error(fmt.Errorf("connection refused"))
+
+	RequireLogged(t, log.LOG_WARN, "disk usage 90")
+	RequireLogged(t, log.LOG_ERROR, "refused")
+
+	tb := &fakeTB{TB: t}
+	tb.run(func() {
+		RequireLogged(tb, log.LOG_INFO, "disk usage")
+	})
+	if len(tb.fatals) != 1 || !strings.Contains(tb.fatals[0], "logtest.Capture(t) is not called") {
+		t.Errorf("RequireLogged without Capture should stop test : %v", tb.fatals)
+	}
+}
+
+func TestRequireLoggedStopsTestWhenMissing(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	defer tb.cleanup()
+	Capture(tb)
+	log.Info("disk usage 90%%")
+
+	tb.run(func() {
+		RequireLogged(tb, log.LOG_WARN, "disk usage")
+	})
+	if len(tb.fatals) != 1 || !strings.Contains(tb.fatals[0], `WARN event containing "disk usage" is not logged`) {
+		t.Errorf("unexpected fatals %v", tb.fatals)
+	}
+}
+
+func TestAssertNoErrors(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	defer tb.cleanup()
+	Capture(tb)
+	log.Warn("warning is allowed")
+	AssertNoErrors(tb)
+	if len(tb.errors) != 0 {
+		t.Fatalf("unexpected errors %v", tb.errors)
+	}
+
+	log.Error("first")
+	log.Error("second")
+	AssertNoErrors(tb)
+	if len(tb.errors) != 2 || !strings.Contains(tb.errors[0], "unexpected error logged : ERROR") {
+		t.Errorf("unexpected errors %v", tb.errors)
+	}
+}
+
+func TestCaptureStopsWhenAnotherTestIsCapturing(t *testing.T) {
+	recorder := Capture(t)
+	if Capture(t) != recorder {
+		t.Errorf("Capture in the same test should return the same recorder")
+	}
+
+	tb := &fakeTB{TB: t}
+	tb.run(func() {
+		Capture(tb)
+	})
+	if len(tb.fatals) != 1 || len(tb.cleanups) != 0 {
+		t.Errorf("second capture should stop test : %v", tb.fatals)
+	}
+}
@@ -0,0 +1,212 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 11:30
+//
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_LOKI_BATCH_SIZE = 500
+	DEFAULT_LOKI_WINDOW     = time.Second
+	DEFAULT_LOKI_TIMEOUT    = time.Second * 10
+	lokiPushPath            = "/loki/api/v1/push"
+	lokiCloseTimeout        = time.Second * 5
+)
+
+var lokiLabelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// LokiConfig is configuration of loki sink
+type LokiConfig struct {
+	Url       string            // loki base url (e.g. http://loki:3100) or push url
+	Labels    map[string]string // static stream labels. keep cardinality low
+	TenantId  string            // X-Scope-OrgID header for multi tenant loki
+	Headers   map[string]string // additional request headers (e.g. authorization)
+	BatchSize int               // max lines in one push. default 500
+	Window    time.Duration     // max wait before push. default 1 second
+	Timeout   time.Duration     // http request timeout. default 10 seconds
+	Profile   JsonProfile       // json schema profile of line. default JSON_PROFILE_DEFAULT
+}
+
+// LokiSink pushes events to grafana loki. stream labels are process, level and static labels.
+// event is pushed as json line so that fields stay in line body
+type LokiSink struct {
+	config LokiConfig
+	client *http.Client
+	worker *batchWorker
+	// last pushed timestamp per stream. loki rejects entries older than the latest of stream
+	lastTime map[string]int64
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiEntry struct {
+	time int64
+	line string
+}
+
+// NewLokiSink creates loki sink. loki does not have to be reachable at creation
+func NewLokiSink(config LokiConfig) (*LokiSink, error) {
+	if len(config.Url) == 0 {
+		return nil, errors.New("empty loki url")
+	}
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid loki url : %s", err.Error())
+	}
+	if len(strings.Trim(u.Path, "/")) == 0 {
+		u.Path = lokiPushPath
+		config.Url = u.String()
+	}
+	for k := range config.Labels {
+		if !lokiLabelNamePattern.MatchString(k) {
+			return nil, fmt.Errorf("invalid loki label name %s", k)
+		}
+	}
+	if config.BatchSize < 1 {
+		config.BatchSize = DEFAULT_LOKI_BATCH_SIZE
+	}
+	if config.Window <= 0 {
+		config.Window = DEFAULT_LOKI_WINDOW
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DEFAULT_LOKI_TIMEOUT
+	}
+
+	sink := LokiSink{}
+	sink.config = config
+	sink.client = &http.Client{Timeout: config.Timeout}
+	sink.lastTime = make(map[string]int64)
+	sink.worker = newBatchWorker(config.BatchSize, config.Window, sink.push)
+	return &sink, nil
+}
+
+// Write queues event without blocking
+func (sink *LokiSink) Write(event LogEvent) error {
+	if !sink.worker.add(event) {
+		return errors.New("loki sink queue is full")
+	}
+	return nil
+}
+
+// Close pushes queued events
+func (sink *LokiSink) Close() error {
+	if !sink.worker.stop(lokiCloseTimeout) {
+		return errors.New("fail to flush loki sink")
+	}
+	return nil
+}
+
+func (sink *LokiSink) buildLabels(level LogLevel) map[string]string {
+	labels := make(map[string]string, len(sink.config.Labels)+2)
+	for k, v := range sink.config.Labels {
+		labels[k] = v
+	}
+	labels["process"] = logPreference.ProcessName
+	labels["level"] = strings.ToLower(level.String())
+	return labels
+}
+
+// push sends events grouped by stream. entries of stream are ordered by time
+// and never older than the last pushed entry of the stream
+func (sink *LokiSink) push(events []LogEvent) error {
+	entries := make(map[LogLevel][]lokiEntry)
+	levels := make([]LogLevel, 0)
+	for _, event := range events {
+		level := event.Level()
+		if _, ok := entries[level]; !ok {
+			levels = append(levels, level)
+		}
+		entries[level] = append(entries[level], lokiEntry{time: event.Time().UnixNano(), line: string(encodeJSONProfile(event, sink.config.Profile))})
+	}
+
+	streams := make([]lokiStream, 0, len(levels))
+	pushed := make(map[string]int64)
+	for _, level := range levels {
+		list := entries[level]
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i].time < list[j].time
+		})
+
+		key := level.String()
+		last := sink.lastTime[key]
+		stream := lokiStream{Stream: sink.buildLabels(level)}
+		for _, entry := range list {
+			if entry.time < last {
+				entry.time = last
+			}
+			last = entry.time
+			stream.Values = append(stream.Values, [2]string{strconv.FormatInt(entry.time, 10), entry.line})
+		}
+		pushed[key] = last
+		streams = append(streams, stream)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"streams": streams})
+	if err != nil {
+		return noRetry(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sink.config.Url, bytes.NewReader(body))
+	if err != nil {
+		return noRetry(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(sink.config.TenantId) > 0 {
+		req.Header.Set("X-Scope-OrgID", sink.config.TenantId)
+	}
+	for k, v := range sink.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := sink.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if err := checkHttpResponse(resp); err != nil {
+		return err
+	}
+	for k, v := range pushed {
+		sink.lastTime[k] = v
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 10:10
+//
+
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+func newLokiTestEvent(level LogLevel, message string, t time.Time) LogEvent {
+	event := newTestEvent(level, nil, message)
+	event.(*GeneralLogEvent).t = t
+	return event
+}
+
+func TestLokiSinkPushesOrderedStreams(t *testing.T) {
+	pushes := make(chan lokiPushRequest, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != lokiPushPath || r.Header.Get("X-Scope-OrgID") != "tenant" {
+			t.Errorf("unexpected request %s %v", r.URL.Path, r.Header)
+		}
+		request := lokiPushRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("fail to decode push : %s", err.Error())
+		}
+		pushes <- request
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink, err := NewLokiSink(LokiConfig{Url: server.URL, Labels: map[string]string{"env": "test"}, TenantId: "tenant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	now := time.Now()
+	err = sink.push([]LogEvent{
+		newLokiTestEvent(LOG_INFO, "later", now.Add(time.Second)),
+		newLokiTestEvent(LOG_ERROR, "failed", now),
+		newLokiTestEvent(LOG_INFO, "earlier", now),
+	})
+	if err != nil {
+		t.Fatalf("push failed : %s", err.Error())
+	}
+
+	request := <-pushes
+	if len(request.Streams) != 2 {
+		t.Fatalf("expected 2 streams, got %d", len(request.Streams))
+	}
+	info := request.Streams[0]
+	if info.Stream["level"] != "info" || info.Stream["env"] != "test" || info.Stream["process"] != logPreference.ProcessName {
+		t.Errorf("unexpected labels %v", info.Stream)
+	}
+	if request.Streams[1].Stream["level"] != "error" {
+		t.Errorf("unexpected labels %v", request.Streams[1].Stream)
+	}
+	if len(info.Values) != 2 {
+		t.Fatalf("expected 2 info lines, got %d", len(info.Values))
+	}
+	first, _ := strconv.ParseInt(info.Values[0][0], 10, 64)
+	second, _ := strconv.ParseInt(info.Values[1][0], 10, 64)
+	if first != now.UnixNano() || second != now.Add(time.Second).UnixNano() {
+		t.Errorf("info lines should be ordered by time : %d, %d", first, second)
+	}
+
+	// entry older than the last pushed entry of stream is pushed with the last timestamp
+	if err := sink.push([]LogEvent{newLokiTestEvent(LOG_INFO, "late", now)}); err != nil {
+		t.Fatalf("push failed : %s", err.Error())
+	}
+	request = <-pushes
+	if ts := request.Streams[0].Values[0][0]; ts != strconv.FormatInt(second, 10) {
+		t.Errorf("old entry should not go back in time, got %s", ts)
+	}
+}
+
+func TestLokiSinkRetriesServerError(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink, err := NewLokiSink(LokiConfig{Url: server.URL, Window: time.Millisecond * 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failures := atomic.LoadUint64(&loggerStats.DeliveryFailures)
+	dropped := atomic.LoadUint64(&loggerStats.DeliveryDropped)
+
+	if err := sink.Write(newTestEvent(LOG_WARN, nil, "retry me")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+	if n := atomic.LoadUint64(&loggerStats.DeliveryFailures) - failures; n != 1 {
+		t.Errorf("expected 1 delivery failure, got %d", n)
+	}
+	if n := atomic.LoadUint64(&loggerStats.DeliveryDropped) - dropped; n != 0 {
+		t.Errorf("retried event should not be dropped, got %d", n)
+	}
+}
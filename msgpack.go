@@ -0,0 +1,372 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 11:30
+//
+
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// msgpackEventTime is fluentd EventTime extension (type 0) with nanosecond precision
+type msgpackEventTime time.Time
+
+// msgpackEncoder writes minimal MessagePack for forward protocol
+type msgpackEncoder struct {
+	buffer bytes.Buffer
+}
+
+func (encoder *msgpackEncoder) bytes() []byte {
+	return encoder.buffer.Bytes()
+}
+
+func (encoder *msgpackEncoder) encode(v interface{}) {
+	switch value := v.(type) {
+	case nil:
+		encoder.buffer.WriteByte(0xc0)
+	case bool:
+		if value {
+			encoder.buffer.WriteByte(0xc3)
+		} else {
+			encoder.buffer.WriteByte(0xc2)
+		}
+	case int:
+		encoder.encodeInt(int64(value))
+	case int8:
+		encoder.encodeInt(int64(value))
+	case int16:
+		encoder.encodeInt(int64(value))
+	case int32:
+		encoder.encodeInt(int64(value))
+	case int64:
+		encoder.encodeInt(value)
+	case uint:
+		encoder.encodeUint(uint64(value))
+	case uint8:
+		encoder.encodeUint(uint64(value))
+	case uint16:
+		encoder.encodeUint(uint64(value))
+	case uint32:
+		encoder.encodeUint(uint64(value))
+	case uint64:
+		encoder.encodeUint(value)
+	case float32:
+		encoder.buffer.WriteByte(0xcb)
+		binary.Write(&encoder.buffer, binary.BigEndian, math.Float64bits(float64(value)))
+	case float64:
+		encoder.buffer.WriteByte(0xcb)
+		binary.Write(&encoder.buffer, binary.BigEndian, math.Float64bits(value))
+	case string:
+		encoder.encodeString(value)
+	case []byte:
+		encoder.encodeBinary(value)
+	case []string:
+		encoder.encodeArrayHeader(len(value))
+		for _, item := range value {
+			encoder.encodeString(item)
+		}
+	case []interface{}:
+		encoder.encodeArrayHeader(len(value))
+		for _, item := range value {
+			encoder.encode(item)
+		}
+	case map[string]interface{}:
+		encoder.encodeMap(value)
+	case Fields:
+		encoder.encodeMap(value)
+	case map[string]string:
+		m := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			m[k] = v
+		}
+		encoder.encodeMap(m)
+	case msgpackEventTime:
+		t := time.Time(value)
+		// fixext 8, type 0 : seconds(uint32), nanoseconds(uint32)
+		encoder.buffer.Write([]byte{0xd7, 0x00})
+		binary.Write(&encoder.buffer, binary.BigEndian, uint32(t.Unix()))
+		binary.Write(&encoder.buffer, binary.BigEndian, uint32(t.Nanosecond()))
+	case time.Time:
+		encoder.encodeString(value.Format(time.RFC3339Nano))
+	case error:
+		encoder.encodeString(value.Error())
+	case fmt.Stringer:
+		encoder.encodeString(value.String())
+	default:
+		encoder.encodeString(fmt.Sprintf("%v", value))
+	}
+}
+
+func (encoder *msgpackEncoder) encodeInt(value int64) {
+	if value >= 0 {
+		encoder.encodeUint(uint64(value))
+		return
+	}
+	if value >= -32 {
+		encoder.buffer.WriteByte(byte(value))
+		return
+	}
+	encoder.buffer.WriteByte(0xd3)
+	binary.Write(&encoder.buffer, binary.BigEndian, value)
+}
+
+func (encoder *msgpackEncoder) encodeUint(value uint64) {
+	switch {
+	case value < 128:
+		encoder.buffer.WriteByte(byte(value))
+	case value <= math.MaxUint8:
+		encoder.buffer.Write([]byte{0xcc, byte(value)})
+	case value <= math.MaxUint16:
+		encoder.buffer.WriteByte(0xcd)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint16(value))
+	case value <= math.MaxUint32:
+		encoder.buffer.WriteByte(0xce)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint32(value))
+	default:
+		encoder.buffer.WriteByte(0xcf)
+		binary.Write(&encoder.buffer, binary.BigEndian, value)
+	}
+}
+
+func (encoder *msgpackEncoder) encodeString(value string) {
+	length := len(value)
+	switch {
+	case length < 32:
+		encoder.buffer.WriteByte(0xa0 | byte(length))
+	case length <= math.MaxUint8:
+		encoder.buffer.Write([]byte{0xd9, byte(length)})
+	case length <= math.MaxUint16:
+		encoder.buffer.WriteByte(0xda)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint16(length))
+	default:
+		encoder.buffer.WriteByte(0xdb)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint32(length))
+	}
+	encoder.buffer.WriteString(value)
+}
+
+func (encoder *msgpackEncoder) encodeBinary(value []byte) {
+	length := len(value)
+	switch {
+	case length <= math.MaxUint8:
+		encoder.buffer.Write([]byte{0xc4, byte(length)})
+	case length <= math.MaxUint16:
+		encoder.buffer.WriteByte(0xc5)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint16(length))
+	default:
+		encoder.buffer.WriteByte(0xc6)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint32(length))
+	}
+	encoder.buffer.Write(value)
+}
+
+func (encoder *msgpackEncoder) encodeArrayHeader(length int) {
+	switch {
+	case length < 16:
+		encoder.buffer.WriteByte(0x90 | byte(length))
+	case length <= math.MaxUint16:
+		encoder.buffer.WriteByte(0xdc)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint16(length))
+	default:
+		encoder.buffer.WriteByte(0xdd)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint32(length))
+	}
+}
+
+func (encoder *msgpackEncoder) encodeMap(value map[string]interface{}) {
+	length := len(value)
+	switch {
+	case length < 16:
+		encoder.buffer.WriteByte(0x80 | byte(length))
+	case length <= math.MaxUint16:
+		encoder.buffer.WriteByte(0xde)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint16(length))
+	default:
+		encoder.buffer.WriteByte(0xdf)
+		binary.Write(&encoder.buffer, binary.BigEndian, uint32(length))
+	}
+
+	keys := make([]string, 0, length)
+	for k := range value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		encoder.encodeString(k)
+		encoder.encode(value[k])
+	}
+}
+
+// decodeMsgpack reads one MessagePack value. map is decoded as map[string]interface{}
+// and ext as []byte. it is enough for reading forward protocol ack response
+func decodeMsgpack(r io.Reader) (interface{}, error) {
+	var head [1]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+
+	b := head[0]
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b&0xf0 == 0x80:
+		return decodeMsgpackMap(r, int(b&0x0f))
+	case b&0xf0 == 0x90:
+		return decodeMsgpackArray(r, int(b&0x0f))
+	case b&0xe0 == 0xa0:
+		return readMsgpackString(r, int(b&0x1f))
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		length, err := readMsgpackLength(r, 1<<(b-0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackBytes(r, length)
+	case 0xca:
+		var v uint32
+		err := binary.Read(r, binary.BigEndian, &v)
+		return float64(math.Float32frombits(v)), err
+	case 0xcb:
+		var v uint64
+		err := binary.Read(r, binary.BigEndian, &v)
+		return math.Float64frombits(v), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		length, err := readMsgpackLength(r, 1<<(b-0xcc))
+		return int64(length), err
+	case 0xd0:
+		var v int8
+		err := binary.Read(r, binary.BigEndian, &v)
+		return int64(v), err
+	case 0xd1:
+		var v int16
+		err := binary.Read(r, binary.BigEndian, &v)
+		return int64(v), err
+	case 0xd2:
+		var v int32
+		err := binary.Read(r, binary.BigEndian, &v)
+		return int64(v), err
+	case 0xd3:
+		var v int64
+		err := binary.Read(r, binary.BigEndian, &v)
+		return v, err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		// fixext : type + 1, 2, 4, 8, 16 bytes
+		return readMsgpackBytes(r, 1+(1<<(b-0xd4)))
+	case 0xc7, 0xc8, 0xc9:
+		length, err := readMsgpackLength(r, 1<<(b-0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackBytes(r, length+1)
+	case 0xd9, 0xda, 0xdb:
+		length, err := readMsgpackLength(r, 1<<(b-0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackString(r, length)
+	case 0xdc, 0xdd:
+		length, err := readMsgpackLength(r, 2<<(b-0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackArray(r, length)
+	case 0xde, 0xdf:
+		length, err := readMsgpackLength(r, 2<<(b-0xde))
+		if err != nil {
+			return nil, err
+		}
+		return decodeMsgpackMap(r, length)
+	}
+	return nil, fmt.Errorf("unsupported msgpack type 0x%x", b)
+}
+
+func readMsgpackLength(r io.Reader, size int) (int, error) {
+	data, err := readMsgpackBytes(r, size)
+	if err != nil {
+		return 0, err
+	}
+	var length uint64
+	for _, b := range data {
+		length = length<<8 | uint64(b)
+	}
+	return int(length), nil
+}
+
+func readMsgpackBytes(r io.Reader, length int) ([]byte, error) {
+	data := make([]byte, length)
+	_, err := io.ReadFull(r, data)
+	return data, err
+}
+
+func readMsgpackString(r io.Reader, length int) (string, error) {
+	data, err := readMsgpackBytes(r, length)
+	return string(data), err
+}
+
+func decodeMsgpackArray(r io.Reader, length int) ([]interface{}, error) {
+	array := make([]interface{}, 0, length)
+	for i := 0; i < length; i++ {
+		item, err := decodeMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, item)
+	}
+	return array, nil
+}
+
+func decodeMsgpackMap(r io.Reader, length int) (map[string]interface{}, error) {
+	m := make(map[string]interface{}, length)
+	for i := 0; i < length; i++ {
+		key, err := decodeMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+		k, ok := key.(string)
+		if !ok {
+			return nil, errors.New("msgpack map key is not string")
+		}
+		m[k] = value
+	}
+	return m, nil
+}
@@ -0,0 +1,329 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 10:40
+//
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DEFAULT_NETWORK_BUFFER_SIZE  = 1024
+	DEFAULT_NETWORK_SPILL_MB     = 100
+	DEFAULT_NETWORK_DIAL_TIMEOUT = time.Second * 5
+	DEFAULT_NETWORK_MIN_BACKOFF  = time.Second
+	DEFAULT_NETWORK_MAX_BACKOFF  = time.Second * 30
+	networkWriteTimeout          = time.Second * 5
+)
+
+// NetworkConfig is configuration of network sink
+type NetworkConfig struct {
+	Network     string        // "tcp" (newline delimited json) or "udp" (one json per datagram)
+	Address     string        // collector host:port
+	BufferSize  int           // in-memory buffer of events. default 1024
+	SpillFile   string        // buffer file while collector is unreachable. default <log folder>/<process>.spill
+	MaxSpillMB  int           // max size of buffer file. default 100
+	DialTimeout time.Duration // default 5 seconds
+	MinBackoff  time.Duration // reconnect backoff. default 1 second
+	MaxBackoff  time.Duration // default 30 seconds
+	Profile     JsonProfile   // json schema profile. default JSON_PROFILE_DEFAULT
+}
+
+// NetworkSink ships events to collector as json without blocking callers.
+// events are spilled to buffer file while collector is unreachable and replayed in order when it comes back
+type NetworkSink struct {
+	config    NetworkConfig
+	queue     chan []byte
+	closeReq  chan chan struct{}
+	closeOnce sync.Once
+	conn      net.Conn
+	backoff   time.Duration
+	nextDial  time.Time
+	spill     *os.File
+	spillSize int64
+}
+
+// NewNetworkSink creates network sink. collector does not have to be reachable at creation
+func NewNetworkSink(config NetworkConfig) (*NetworkSink, error) {
+	switch config.Network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return nil, fmt.Errorf("unsupported network %s", config.Network)
+	}
+	if len(config.Address) == 0 {
+		return nil, errors.New("empty collector address")
+	}
+	if config.BufferSize < 1 {
+		config.BufferSize = DEFAULT_NETWORK_BUFFER_SIZE
+	}
+	if len(config.SpillFile) == 0 && len(logPreference.logFolder) > 0 {
+		config.SpillFile = filepath.Join(logPreference.logFolder, logPreference.ProcessName+".spill")
+	}
+	if config.MaxSpillMB < 1 {
+		config.MaxSpillMB = DEFAULT_NETWORK_SPILL_MB
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = DEFAULT_NETWORK_DIAL_TIMEOUT
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = DEFAULT_NETWORK_MIN_BACKOFF
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = DEFAULT_NETWORK_MAX_BACKOFF
+	}
+
+	sink := NetworkSink{}
+	sink.config = config
+	sink.queue = make(chan []byte, config.BufferSize)
+	sink.closeReq = make(chan chan struct{})
+	if err := sink.openSpill(); err != nil {
+		return nil, err
+	}
+	go sink.run()
+	return &sink, nil
+}
+
+// Write encodes event and queues it without blocking
+func (sink *NetworkSink) Write(event LogEvent) error {
+	select {
+	case sink.queue <- encodeJSONProfile(event, sink.config.Profile):
+		return nil
+	default:
+		atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+		return errors.New("network sink buffer is full")
+	}
+}
+
+// Close sends (or spills) buffered events and closes connection. later calls do nothing
+func (sink *NetworkSink) Close() error {
+	sink.closeOnce.Do(func() {
+		done := make(chan struct{})
+		sink.closeReq <- done
+		<-done
+	})
+	return nil
+}
+
+func (sink *NetworkSink) run() {
+	ticker := time.NewTicker(sink.config.MinBackoff)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case line := <-sink.queue:
+			sink.handle(line)
+		case <-ticker.C:
+			if sink.conn == nil && sink.spillSize > 0 {
+				sink.ensureConnected()
+			}
+		case done := <-sink.closeReq:
+			sink.drain()
+			if sink.conn != nil {
+				sink.conn.Close()
+			}
+			if sink.spill != nil {
+				sink.spill.Close()
+			}
+			close(done)
+			return
+		}
+	}
+}
+
+func (sink *NetworkSink) drain() {
+	for {
+		select {
+		case line := <-sink.queue:
+			sink.handle(line)
+		default:
+			return
+		}
+	}
+}
+
+func (sink *NetworkSink) handle(line []byte) {
+	sink.ensureConnected()
+
+	// while buffer file has events, new events go behind them to keep order
+	if sink.conn != nil && sink.spillSize == 0 {
+		if err := sink.send(line); err == nil {
+			return
+		}
+		sink.disconnect()
+	}
+	sink.writeSpill(line)
+}
+
+func (sink *NetworkSink) send(line []byte) error {
+	sink.conn.SetWriteDeadline(time.Now().Add(networkWriteTimeout))
+	if sink.isStream() {
+		_, err := sink.conn.Write(append(line, '\n'))
+		return err
+	}
+	_, err := sink.conn.Write(line)
+	return err
+}
+
+func (sink *NetworkSink) isStream() bool {
+	switch sink.config.Network {
+	case "udp", "udp4", "udp6":
+		return false
+	}
+	return true
+}
+
+// ensureConnected dials collector when backoff passed and replays buffer file
+func (sink *NetworkSink) ensureConnected() {
+	if sink.conn != nil || time.Now().Before(sink.nextDial) {
+		return
+	}
+
+	conn, err := net.DialTimeout(sink.config.Network, sink.config.Address, sink.config.DialTimeout)
+	if err != nil {
+		atomic.AddUint64(&loggerStats.DeliveryFailures, 1)
+		if sink.backoff == 0 {
+			sink.backoff = sink.config.MinBackoff
+		} else if sink.backoff < sink.config.MaxBackoff {
+			sink.backoff *= 2
+			if sink.backoff > sink.config.MaxBackoff {
+				sink.backoff = sink.config.MaxBackoff
+			}
+		}
+		sink.nextDial = time.Now().Add(sink.backoff)
+		return
+	}
+
+	sink.conn = conn
+	sink.backoff = 0
+	sink.replaySpill()
+}
+
+func (sink *NetworkSink) disconnect() {
+	atomic.AddUint64(&loggerStats.DeliveryFailures, 1)
+	sink.conn.Close()
+	sink.conn = nil
+	sink.backoff = sink.config.MinBackoff
+	sink.nextDial = time.Now().Add(sink.backoff)
+}
+
+func (sink *NetworkSink) openSpill() error {
+	if len(sink.config.SpillFile) == 0 {
+		return nil
+	}
+
+	file, err := os.OpenFile(sink.config.SpillFile, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	sink.spill = file
+	sink.spillSize = stat.Size()
+	return nil
+}
+
+func (sink *NetworkSink) writeSpill(line []byte) {
+	if sink.spill == nil || sink.spillSize+int64(len(line))+1 > int64(sink.config.MaxSpillMB)*1024*1024 {
+		atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+		return
+	}
+
+	n, err := sink.spill.Write(append(line, '\n'))
+	sink.spillSize += int64(n)
+	if err != nil {
+		atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+	}
+}
+
+// replaySpill sends events in buffer file in order, line by line. unsent events are kept in buffer file
+func (sink *NetworkSink) replaySpill() {
+	if sink.spill == nil || sink.spillSize == 0 {
+		return
+	}
+
+	file, err := os.Open(sink.config.SpillFile)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		payload := bytes.TrimSuffix(line, []byte{'\n'})
+		if len(payload) > 0 {
+			if sendErr := sink.send(payload); sendErr != nil {
+				sink.disconnect()
+				sink.keepSpill(line, reader)
+				return
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	// every event is sent
+	if err := sink.spill.Truncate(0); err == nil {
+		sink.spillSize = 0
+	}
+}
+
+// keepSpill replaces buffer file with unsent line and rest of reader
+func (sink *NetworkSink) keepSpill(line []byte, rest io.Reader) {
+	tempFile := sink.config.SpillFile + ".tmp"
+	temp, err := os.OpenFile(tempFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	_, err = temp.Write(line)
+	if err == nil {
+		_, err = io.Copy(temp, rest)
+	}
+	temp.Close()
+	if err == nil {
+		err = os.Rename(tempFile, sink.config.SpillFile)
+	}
+	if err != nil {
+		os.Remove(tempFile)
+		return
+	}
+
+	sink.spill.Close()
+	sink.spill = nil
+	sink.spillSize = 0
+	sink.openSpill()
+}
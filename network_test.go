@@ -0,0 +1,238 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 10:40
+//
+
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// acceptTestLines accepts connections of listener and sends received lines to channel
+func acceptTestLines(listener net.Listener) chan string {
+	lines := make(chan string, 16)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					lines <- scanner.Text()
+				}
+			}()
+		}
+	}()
+	return lines
+}
+
+func receiveTestLine(t *testing.T, lines chan string) map[string]interface{} {
+	t.Helper()
+
+	select {
+	case line := <-lines:
+		var object map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &object); err != nil {
+			t.Fatalf("invalid json line %q : %s", line, err)
+		}
+		return object
+	case <-time.After(3 * time.Second):
+		t.Fatalf("no line received")
+	}
+	return nil
+}
+
+func TestEncodeJSON(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	event := newTestEvent(LOG_ERROR, Fields{"user": "bob", "level": "overridden"}, "failed", errors.New("boom"))
+
+	encoded := string(encodeJSON(event))
+	if !strings.HasPrefix(encoded, `{"time":"`) || !strings.Contains(encoded, `"level":"ERROR","process":"test","message":"failed","file":"`) {
+		t.Errorf("standard keys should come first in order, got %s", encoded)
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(encoded), &object); err != nil {
+		t.Fatal(err)
+	}
+	if object["error"] != "boom" || object["error_type"] != "*errors.errorString" || object["user"] != "bob" || object["level"] != "ERROR" {
+		t.Errorf("unexpected object %v", object)
+	}
+	if trace, ok := object["trace"].([]interface{}); !ok || len(trace) != 1 {
+		t.Errorf("unexpected trace %v", object["trace"])
+	}
+}
+
+func TestNetworkSinkTcp(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	lines := acceptTestLines(listener)
+
+	sink, err := NewNetworkSink(NetworkConfig{Network: "tcp", Address: listener.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_INFO, nil, "first"))
+	sink.Write(newTestEvent(LOG_WARN, nil, "second"))
+	for _, expected := range []string{"first", "second"} {
+		if object := receiveTestLine(t, lines); object["message"] != expected {
+			t.Errorf("expected %s, got %v", expected, object)
+		}
+	}
+}
+
+func TestNetworkSinkUdp(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, read := listenTestUdp(t)
+
+	sink, err := NewNetworkSink(NetworkConfig{Network: "udp", Address: address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_INFO, Fields{"n": 1}, "datagram"))
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(read()), &object); err != nil {
+		t.Fatal(err)
+	}
+	if object["message"] != "datagram" || object["n"] != float64(1) {
+		t.Errorf("unexpected object %v", object)
+	}
+}
+
+func TestNetworkSinkSpillsAndReplaysInOrder(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+
+	// reserve address which is not listening yet
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	spillFile := filepath.Join(t.TempDir(), "test.spill")
+	sink, err := NewNetworkSink(NetworkConfig{
+		Network:    "tcp",
+		Address:    address,
+		SpillFile:  spillFile,
+		MinBackoff: 50 * time.Millisecond,
+		MaxBackoff: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	for _, message := range []string{"one", "two", "three"} {
+		sink.Write(newTestEvent(LOG_INFO, nil, message))
+	}
+
+	// collector comes back
+	time.Sleep(100 * time.Millisecond)
+	listener, err = net.Listen("tcp", address)
+	if err != nil {
+		t.Skipf("fail to listen reserved address : %s", err)
+	}
+	defer listener.Close()
+	lines := acceptTestLines(listener)
+
+	for _, expected := range []string{"one", "two", "three"} {
+		if object := receiveTestLine(t, lines); object["message"] != expected {
+			t.Errorf("expected %s, got %v", expected, object)
+		}
+	}
+}
+
+func TestNetworkSinkInvalidConfig(t *testing.T) {
+	if _, err := NewNetworkSink(NetworkConfig{Network: "unix", Address: "/tmp/sock"}); err == nil {
+		t.Errorf("unsupported network should be rejected")
+	}
+	if _, err := NewNetworkSink(NetworkConfig{Network: "tcp"}); err == nil {
+		t.Errorf("empty address should be rejected")
+	}
+}
+
+func TestNetworkSinkCloseTwice(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, _ := listenTestUdp(t)
+
+	sink, err := NewNetworkSink(NetworkConfig{Network: "udp", Address: address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.Close()
+	sink.Close()
+}
+
+func TestNetworkSinkReplaysSpillFileOfPreviousRun(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	lines := acceptTestLines(listener)
+
+	// events spilled by previous run
+	spillFile := filepath.Join(t.TempDir(), "test.spill")
+	previous := string(encodeJSON(newTestEvent(LOG_INFO, nil, "old 1"))) + "\n" +
+		string(encodeJSON(newTestEvent(LOG_INFO, nil, "old 2"))) + "\n"
+	if err := ioutil.WriteFile(spillFile, []byte(previous), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	sink, err := NewNetworkSink(NetworkConfig{Network: "tcp", Address: listener.Addr().String(), SpillFile: spillFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.Write(newTestEvent(LOG_INFO, nil, "new"))
+
+	for _, expected := range []string{"old 1", "old 2", "new"} {
+		if object := receiveTestLine(t, lines); object["message"] != expected {
+			t.Errorf("expected %s, got %v", expected, object)
+		}
+	}
+	sink.Close()
+	if stat, err := os.Stat(spillFile); err != nil || stat.Size() != 0 {
+		t.Errorf("replayed spill file should be emptied, %v", err)
+	}
+}
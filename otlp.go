@@ -0,0 +1,310 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 10:20
+//
+
+package log
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_OTLP_ENDPOINT   = "http://localhost:4318/v1/logs"
+	DEFAULT_OTLP_BATCH_SIZE = 512
+	DEFAULT_OTLP_WINDOW     = time.Second
+	DEFAULT_OTLP_TIMEOUT    = time.Second * 10
+	otlpScopeName           = "throosea.com/log"
+	otlpCloseTimeout        = time.Second * 5
+)
+
+// OtlpConfig is configuration of OpenTelemetry log exporter
+type OtlpConfig struct {
+	Endpoint           string            // OTLP/HTTP logs endpoint. default http://localhost:4318/v1/logs
+	Headers            map[string]string // additional request headers (e.g. authorization)
+	ServiceName        string            // service.name resource attribute. default ProcessName
+	ResourceAttributes map[string]string // additional resource attributes
+	BatchSize          int               // max log records in one request. default 512
+	Window             time.Duration     // max wait before sending batch. default 1 second
+	Timeout            time.Duration     // http request timeout. default 10 seconds
+}
+
+// OtlpSink exports events as OTLP log records over HTTP with json encoding
+type OtlpSink struct {
+	config   OtlpConfig
+	client   *http.Client
+	resource otlpResource
+	worker   *batchWorker
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otlpAnyValue   `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes"`
+	TraceId              string         `json:"traceId,omitempty"`
+	SpanId               string         `json:"spanId,omitempty"`
+}
+
+type otlpScopeLogs struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+// NewOtlpSink creates OTLP log exporter. collector does not have to be reachable at creation
+func NewOtlpSink(config OtlpConfig) (*OtlpSink, error) {
+	if len(config.Endpoint) == 0 {
+		config.Endpoint = DEFAULT_OTLP_ENDPOINT
+	}
+	if !strings.HasPrefix(config.Endpoint, "http://") && !strings.HasPrefix(config.Endpoint, "https://") {
+		return nil, fmt.Errorf("invalid otlp endpoint %s", config.Endpoint)
+	}
+	if len(config.ServiceName) == 0 {
+		config.ServiceName = logPreference.ProcessName
+	}
+	if config.BatchSize < 1 {
+		config.BatchSize = DEFAULT_OTLP_BATCH_SIZE
+	}
+	if config.Window <= 0 {
+		config.Window = DEFAULT_OTLP_WINDOW
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DEFAULT_OTLP_TIMEOUT
+	}
+
+	sink := OtlpSink{}
+	sink.config = config
+	sink.client = &http.Client{Timeout: config.Timeout}
+	sink.resource.Attributes = []otlpKeyValue{newOtlpKeyValue("service.name", config.ServiceName)}
+	for _, k := range sortedStringKeys(config.ResourceAttributes) {
+		if k != "service.name" {
+			sink.resource.Attributes = append(sink.resource.Attributes, newOtlpKeyValue(k, config.ResourceAttributes[k]))
+		}
+	}
+	sink.worker = newBatchWorker(config.BatchSize, config.Window, sink.post)
+	return &sink, nil
+}
+
+// Write queues event without blocking
+func (sink *OtlpSink) Write(event LogEvent) error {
+	if !sink.worker.add(event) {
+		return errors.New("otlp sink queue is full")
+	}
+	return nil
+}
+
+// Close exports queued events
+func (sink *OtlpSink) Close() error {
+	if !sink.worker.stop(otlpCloseTimeout) {
+		return errors.New("fail to flush otlp sink")
+	}
+	return nil
+}
+
+func (sink *OtlpSink) post(events []LogEvent) error {
+	scopeLogs := otlpScopeLogs{}
+	scopeLogs.Scope.Name = otlpScopeName
+	observed := time.Now()
+	for _, event := range events {
+		scopeLogs.LogRecords = append(scopeLogs.LogRecords, buildOtlpLogRecord(event, observed))
+	}
+	request := otlpRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource:  sink.resource,
+		ScopeLogs: []otlpScopeLogs{scopeLogs},
+	}}}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return noRetry(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sink.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return noRetry(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range sink.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := sink.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	return checkHttpResponse(resp)
+}
+
+// buildOtlpLogRecord maps event to log record with code.* and exception.* semantic attributes.
+// trace_id, span_id fields are used as trace context when they are valid hex ids
+func buildOtlpLogRecord(event LogEvent, observed time.Time) otlpLogRecord {
+	record := otlpLogRecord{}
+	record.TimeUnixNano = strconv.FormatInt(event.Time().UnixNano(), 10)
+	record.ObservedTimeUnixNano = strconv.FormatInt(observed.UnixNano(), 10)
+	record.SeverityNumber = toOtlpSeverity(event.Level())
+	record.SeverityText = event.Level().String()
+	record.Body = toOtlpAnyValue(event.Message())
+
+	function := event.Function()
+	record.Attributes = []otlpKeyValue{
+		newOtlpKeyValue("code.filepath", event.File()),
+		newOtlpKeyValue("code.lineno", event.Line()),
+	}
+	if namespace, name := splitFunctionName(function); len(namespace) > 0 {
+		record.Attributes = append(record.Attributes,
+			newOtlpKeyValue("code.namespace", namespace),
+			newOtlpKeyValue("code.function", name))
+	} else {
+		record.Attributes = append(record.Attributes, newOtlpKeyValue("code.function", function))
+	}
+
+	if event.Err() != nil {
+		record.Attributes = append(record.Attributes,
+			newOtlpKeyValue("exception.type", errorTypeName(event.Err())),
+			newOtlpKeyValue("exception.message", event.Err().Error()),
+			newOtlpKeyValue("exception.stacktrace", strings.Join(buildTraceLines(event.Trace()), "\n")))
+	}
+
+	fields := event.Fields()
+	for _, k := range sortedFieldKeys(fields) {
+		switch k {
+		case FIELD_TRACE_ID:
+			if id, ok := toOtlpId(fields[k], 16); ok {
+				record.TraceId = id
+				continue
+			}
+		case FIELD_SPAN_ID:
+			if id, ok := toOtlpId(fields[k], 8); ok {
+				record.SpanId = id
+				continue
+			}
+		}
+		record.Attributes = append(record.Attributes, newOtlpKeyValue(k, fields[k]))
+	}
+	return record
+}
+
+// toOtlpSeverity returns severity number. TRACE=1, DEBUG=5, INFO=9, WARN=13, ERROR=17
+func toOtlpSeverity(level LogLevel) int {
+	switch level {
+	case LOG_ERROR:
+		return 17
+	case LOG_WARN:
+		return 13
+	case LOG_INFO:
+		return 9
+	case LOG_DEBUG:
+		return 5
+	}
+	return 1
+}
+
+// toOtlpId returns lower case hex id when value is hex string of given byte size
+func toOtlpId(value interface{}, size int) (string, bool) {
+	s, ok := value.(string)
+	if !ok || len(s) != size*2 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return "", false
+	}
+	return strings.ToLower(s), true
+}
+
+func newOtlpKeyValue(key string, value interface{}) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: toOtlpAnyValue(value)}
+}
+
+func toOtlpAnyValue(value interface{}) otlpAnyValue {
+	anyValue := otlpAnyValue{}
+	switch v := value.(type) {
+	case string:
+		anyValue.StringValue = &v
+	case bool:
+		anyValue.BoolValue = &v
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		// int64 is encoded as json string
+		s := fmt.Sprintf("%d", v)
+		anyValue.IntValue = &s
+	case float32:
+		return toOtlpDoubleValue(float64(v))
+	case float64:
+		return toOtlpDoubleValue(v)
+	default:
+		s := fmt.Sprintf("%v", v)
+		anyValue.StringValue = &s
+	}
+	return anyValue
+}
+
+// toOtlpDoubleValue stringifies NaN and Inf which json can not encode
+func toOtlpDoubleValue(f float64) otlpAnyValue {
+	anyValue := otlpAnyValue{}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		anyValue.StringValue = &s
+		return anyValue
+	}
+	anyValue.DoubleValue = &f
+	return anyValue
+}
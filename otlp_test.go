@@ -0,0 +1,159 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 20. AM 10:10
+//
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func otlpAttributes(record otlpLogRecord) map[string]otlpAnyValue {
+	attributes := make(map[string]otlpAnyValue)
+	for _, kv := range record.Attributes {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+func TestOtlpSinkExportsLogRecords(t *testing.T) {
+	requests := make(chan otlpRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		request := otlpRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("fail to decode request : %s", err.Error())
+		}
+		requests <- request
+	}))
+	defer server.Close()
+
+	sink, err := NewOtlpSink(OtlpConfig{
+		Endpoint:    server.URL,
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		ServiceName: "queryman",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	traceId := "4BF92F3577B34DA6A3CE929D0E0E4736"
+	events := []LogEvent{
+		newTestEvent(LOG_INFO, Fields{FIELD_TRACE_ID: traceId, FIELD_SPAN_ID: "00f067aa0ba902b7", "ratio": math.NaN(), "count": 3}, "hello"),
+		newTestEvent(LOG_ERROR, Fields{FIELD_TRACE_ID: "not-a-trace-id"}, "failed", errors.New("boom")),
+		newTestEvent(LOG_DEBUG, nil, "debug"),
+		newTestEvent(LOG_WARN, Fields{"limit": math.Inf(1)}, "warn"),
+	}
+	if err := sink.post(events); err != nil {
+		t.Fatalf("post failed : %s", err.Error())
+	}
+
+	request := <-requests
+	resource := request.ResourceLogs[0].Resource.Attributes
+	if resource[0].Key != "service.name" || *resource[0].Value.StringValue != "queryman" {
+		t.Errorf("unexpected resource %v", resource)
+	}
+	records := request.ResourceLogs[0].ScopeLogs[0].LogRecords
+	if len(records) != len(events) {
+		t.Fatalf("expected %d records, got %d", len(events), len(records))
+	}
+
+	for i, severity := range []int{9, 17, 5, 13} {
+		if records[i].SeverityNumber != severity || records[i].SeverityText != events[i].Level().String() {
+			t.Errorf("record %d : severity %d %s", i, records[i].SeverityNumber, records[i].SeverityText)
+		}
+	}
+
+	info := records[0]
+	if info.TraceId != strings.ToLower(traceId) || info.SpanId != "00f067aa0ba902b7" {
+		t.Errorf("unexpected trace context %s / %s", info.TraceId, info.SpanId)
+	}
+	attributes := otlpAttributes(info)
+	if filepath := attributes["code.filepath"]; filepath.StringValue == nil || !strings.HasSuffix(*filepath.StringValue, "otlp_test.go") {
+		t.Errorf("unexpected code.filepath %v", filepath)
+	}
+	if lineno := attributes["code.lineno"]; lineno.IntValue == nil {
+		t.Errorf("code.lineno should be int value")
+	}
+	if _, ok := attributes["code.function"]; !ok {
+		t.Errorf("code.function is missing")
+	}
+	if ratio := attributes["ratio"]; ratio.StringValue == nil || *ratio.StringValue != "NaN" {
+		t.Errorf("NaN should be stringified, got %v", ratio)
+	}
+	if count := attributes["count"]; count.IntValue == nil || *count.IntValue != "3" {
+		t.Errorf("unexpected count %v", count)
+	}
+	if _, ok := attributes[FIELD_TRACE_ID]; ok {
+		t.Errorf("valid trace id should not be attribute")
+	}
+
+	failed := records[1]
+	if len(failed.TraceId) != 0 {
+		t.Errorf("invalid trace id should not be trace context")
+	}
+	attributes = otlpAttributes(failed)
+	if message := attributes["exception.message"]; message.StringValue == nil || *message.StringValue != "boom" {
+		t.Errorf("unexpected exception.message %v", message)
+	}
+	if _, ok := attributes["exception.type"]; !ok {
+		t.Errorf("exception.type is missing")
+	}
+	if stacktrace := attributes["exception.stacktrace"]; stacktrace.StringValue == nil || len(*stacktrace.StringValue) == 0 {
+		t.Errorf("exception.stacktrace is missing")
+	}
+	if traceAttribute := attributes[FIELD_TRACE_ID]; traceAttribute.StringValue == nil {
+		t.Errorf("invalid trace id should be kept as attribute")
+	}
+
+	if limit := otlpAttributes(records[3])["limit"]; limit.StringValue == nil || *limit.StringValue != "+Inf" {
+		t.Errorf("Inf should be stringified, got %v", limit)
+	}
+}
+
+func TestOtlpSinkDoesNotRetryClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sink, err := NewOtlpSink(OtlpConfig{Endpoint: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	err = sink.post([]LogEvent{newTestEvent(LOG_INFO, nil, "hello")})
+	var noRetryErr noRetryError
+	if !errors.As(err, &noRetryErr) {
+		t.Errorf("400 should not be retried, got %v", err)
+	}
+}
@@ -26,21 +26,31 @@ package log
 import (
 	"fmt"
 	"github.com/getsentry/sentry-go"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	tagEnvironment	= "environment"
 	tagServerName	= "serverName"
 	tagProcess 		= "process"
+	tagRelease		= "release"
+	tagPid			= "pid"
 )
 
-var sentryConnect = false
+// sentryReporter reports events to sentry through hub per level
+type sentryReporter struct {
+	errorHub	*sentry.Hub
+	warnHub		*sentry.Hub
+	infoHub		*sentry.Hub
+}
 
-var (
-	sentryError	*sentry.Hub
-	sentryWarn	*sentry.Hub
-	sentryInfo	*sentry.Hub
-)
+// activeSentryReporter is replaced when SentryInit is called again
+var activeSentryReporter *sentryReporter
 
 func SentryInit()	{
 	Info("sentry initializing.. dsn=[%s], level=[%s]", logPreference.sentryDsn, logPreference.sentryLogLevel)
@@ -57,14 +67,35 @@ func SentryInit()	{
 
 	var environment string
 	var serverName string
-	var process string
+	var release string
+	tags := make(map[string]string)
 
-	if logPreference.sentryTag != nil {
-		environment, _ = logPreference.sentryTag[tagEnvironment]
-		serverName, _ = logPreference.sentryTag[tagServerName]
-		process, _ = logPreference.sentryTag[tagProcess]
+	// environment, serverName and release are client options. others are applied to scope
+	for k, v := range redactTags(logPreference.sentryTag) {
+		switch k {
+		case tagEnvironment :
+			environment = v
+		case tagServerName :
+			serverName = v
+		case tagRelease :
+			release = v
+		default :
+			tags[k] = v
+		}
 	}
 
+	if len(serverName) == 0 {
+		serverName, _ = os.Hostname()
+	}
+	if len(release) == 0 {
+		release = detectRelease()
+	}
+	if _, ok := tags[tagProcess]; !ok {
+		tags[tagProcess] = logPreference.ProcessName
+	}
+	if _, ok := tags[tagPid]; !ok {
+		tags[tagPid] = strconv.Itoa(os.Getpid())
+	}
 
 	err := sentry.Init(sentry.ClientOptions{
 		// Either set your DSN here or set the SENTRY_DSN environment variable.
@@ -74,6 +105,8 @@ func SentryInit()	{
 		Debug: false,
 		Environment: environment,
 		ServerName: serverName,
+		Release: release,
+		MaxBreadcrumbs: logPreference.sentryMaxBreadcrumbs,
 	})
 
 	if err != nil {
@@ -81,50 +114,243 @@ func SentryInit()	{
 		return
 	}
 
+	startSentryDelivery()
+
 	sentry.ConfigureScope(func(scope *sentry.Scope) {
-		scope.SetTag("process", process)
+		scope.SetTags(tags)
 	})
 
+	reporter := &sentryReporter{}
 	if logPreference.sentryLogLevel >= LOG_INFO {
-		sentryInfo = sentry.CurrentHub().Clone()
-		sentryInfo.Scope().SetLevel(sentry.LevelInfo)
+		reporter.infoHub = sentry.CurrentHub().Clone()
+		reporter.infoHub.Scope().SetLevel(sentry.LevelInfo)
 	}
 	if logPreference.sentryLogLevel >= LOG_WARN {
-		sentryWarn = sentry.CurrentHub().Clone()
-		sentryWarn.Scope().SetLevel(sentry.LevelWarning)
+		reporter.warnHub = sentry.CurrentHub().Clone()
+		reporter.warnHub.Scope().SetLevel(sentry.LevelWarning)
 	}
 	if logPreference.sentryLogLevel >= LOG_ERROR {
-		sentryError = sentry.CurrentHub().Clone()
-		sentryError.Scope().SetLevel(sentry.LevelError)
+		reporter.errorHub = sentry.CurrentHub().Clone()
+		reporter.errorHub.Scope().SetLevel(sentry.LevelError)
 	}
 
-	sentryConnect = true
+	if activeSentryReporter != nil {
+		replaceReporter(activeSentryReporter, reporter)
+	} else {
+		AddReporter(reporter)
+	}
+	activeSentryReporter = reporter
 }
 
-func sentrySendMessage(level LogLevel, message string)	{
-	hub := getSentryHub(level)
-	if hub != nil {
-		hub.CaptureMessage(message)
+func (reporter *sentryReporter) ReportMessage(event LogEvent)	{
+	reporter.sendMessage(event)
+	reporter.addBreadcrumb(event)
+}
+
+func (reporter *sentryReporter) ReportException(event LogEvent)	{
+	reporter.sendException(event)
+	reporter.addBreadcrumb(event)
+}
+
+// Flush waits until queued events are handed over to sentry sdk and sent.
+// timeout is limited by SetSentryFlushSecond
+func (reporter *sentryReporter) Flush(timeout time.Duration)	bool	{
+	if limit := time.Duration(logPreference.sentryFlushSecond) * time.Second; timeout > limit {
+		timeout = limit
+	}
+	start := time.Now()
+	if !waitForSentryDelivered(timeout) {
+		return false
 	}
+	return sentry.Flush(timeout - time.Since(start))
 }
 
+func (reporter *sentryReporter) sendMessage(event LogEvent)	{
+	hub := reporter.getHub(event.Level())
+	if hub == nil || hub.Client() == nil {
+		return
+	}
 
-func sentrySendException(level LogLevel, err error)	{
-	hub := getSentryHub(level)
-	if hub != nil {
-		hub.CaptureException(err)
+	message := event.Message()
+	fields := event.Fields()
+	tags := event.Tags()
+	fingerprint := buildSentryFingerprint(event.Level(), event.File(), event.Line(), "message")
+	enqueueSentryEvent(fingerprint, func() {
+		countSentrySend(hub.Client().CaptureMessage(message, nil, buildSentryScope(hub, fields, tags)))
+	})
+}
+
+
+func (reporter *sentryReporter) sendException(event LogEvent)	{
+	hub := reporter.getHub(event.Level())
+	if hub == nil || hub.Client() == nil {
+		return
+	}
+
+	hint := &sentry.EventHint{OriginalException: event.Err()}
+	if event.Recovered() != nil {
+		hint.RecoveredException = event.Recovered()
+	}
+	sentryEvent := buildSentryExceptionEvent(event)
+	fields := event.Fields()
+	tags := event.Tags()
+	fingerprint := buildSentryFingerprint(event.Level(), event.File(), event.Line(), errorTypeName(event.Err()))
+	enqueueSentryEvent(fingerprint, func() {
+		scope := buildSentryScope(hub, fields, tags)
+		// scope level overrides event level
+		scope.SetLevel(sentryEvent.Level)
+		countSentrySend(hub.Client().CaptureEvent(sentryEvent, hint, scope))
+	})
+}
+
+// buildSentryExceptionEvent builds sentry event from trace points which logger already collected
+func buildSentryExceptionEvent(event LogEvent)	*sentry.Event	{
+	sentryEvent := sentry.NewEvent()
+	sentryEvent.Level = toSentryLevel(event.Level())
+	if event.Recovered() != nil {
+		sentryEvent.Level = sentry.LevelFatal
+	}
+	sentryEvent.Message = event.Message()
+	// sentry shows transaction as culprit
+	sentryEvent.Transaction = fmt.Sprintf("%s (%s:%d)",
+		event.Function(), buildSourcePath(event.File()), event.Line())
+
+	// sentry expects the most recent frame last
+	trace := event.Trace()
+	frames := make([]sentry.Frame, 0, len(trace)+1)
+	for i := len(trace)-1; i >= 0; i-- {
+		frames = append(frames, buildSentryFrame(trace[i].Function(), trace[i].File(), trace[i].Line()))
+	}
+	frames = append(frames, buildSentryFrame(event.Function(), event.File(), event.Line()))
+
+	sentryEvent.Exception = []sentry.Exception{{
+		Type: errorTypeName(event.Err()),
+		Value: event.Err().Error(),
+		Stacktrace: &sentry.Stacktrace{Frames: frames},
+	}}
+	return sentryEvent
+}
+
+func buildSentryFrame(functionName string, file string, line int)	sentry.Frame	{
+	module, function := splitFunctionName(functionName)
+	return sentry.Frame{
+		Function: function,
+		Module: module,
+		Filename: filepath.Base(file),
+		AbsPath: file,
+		Lineno: line,
+		InApp: isInAppFrame(module, file),
 	}
 }
 
-func getSentryHub(level LogLevel)	*sentry.Hub	{
-	if !sentryConnect	{
-		return nil
+// splitFunctionName splits "throosea.com/log.(*logFile).write" to "throosea.com/log", "(*logFile).write"
+func splitFunctionName(name string)	(string, string)	{
+	lastSlash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[lastSlash+1:], ".")
+	if dot < 0 {
+		return "", name
 	}
+	dot += lastSlash+1
+	return name[:dot], name[dot+1:]
+}
+
+func isInAppFrame(module string, file string)	bool	{
+	if module == "main" {
+		return true
+	}
+	// standard library package has no dot in first path element
+	if !strings.Contains(strings.SplitN(module, "/", 2)[0], ".") {
+		return false
+	}
+	return !strings.Contains(file, "/vendor/") && !strings.Contains(file, "/pkg/mod/")
+}
+
+// addBreadcrumb records event as breadcrumb on every hub
+// so that next sentry event carries log lines leading up to it
+func (reporter *sentryReporter) addBreadcrumb(event LogEvent)	{
+	if logPreference.sentryBreadcrumbLevel < event.Level() {
+		return
+	}
+	// event reported to sentry is not breadcrumb of next events
+	if hub := reporter.getHub(event.Level()); hub != nil && hub.Client() != nil {
+		return
+	}
+
+	breadcrumb := &sentry.Breadcrumb{
+		Category: "log",
+		Message: event.Message(),
+		Level: toSentryLevel(event.Level()),
+		Timestamp: event.Time(),
+	}
+	if len(event.Fields()) > 0 {
+		breadcrumb.Data = event.Fields()
+	}
+
+	hubs := make([]*sentry.Hub, 0, 3)
+	for _, hub := range []*sentry.Hub{reporter.errorHub, reporter.warnHub, reporter.infoHub} {
+		if hub != nil {
+			hubs = append(hubs, hub)
+		}
+	}
+	if len(hubs) == 0 {
+		return
+	}
+	// breadcrumb is not rate limited and does not take queue slot of events
+	sentryBreadcrumbs.add(hubs, breadcrumb)
+}
+
+func toSentryLevel(level LogLevel)	sentry.Level	{
+	switch level {
+	case LOG_ERROR : return sentry.LevelError
+	case LOG_WARN : return sentry.LevelWarning
+	case LOG_INFO : return sentry.LevelInfo
+	}
+	return sentry.LevelDebug
+}
+
+// buildSentryScope returns copy of hub scope with event fields and tags.
+// trace/span id are set as tags, other fields as extra. event tags override global tags
+func buildSentryScope(hub *sentry.Hub, fields Fields, tags map[string]string)	*sentry.Scope	{
+	scope := hub.Scope().Clone()
+	for k, v := range fields {
+		switch k {
+		case FIELD_TRACE_ID, FIELD_SPAN_ID :
+			scope.SetTag(k, fmt.Sprintf("%v", v))
+		default :
+			scope.SetExtra(k, v)
+		}
+	}
+	if len(tags) > 0 {
+		scope.SetTags(tags)
+	}
+	return scope
+}
+
+// detectRelease returns module version or vcs revision of main module
+func detectRelease()	string	{
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	version := info.Main.Version
+	if len(version) == 0 || version == "(devel)" {
+		version = readVcsRevision(info)
+	}
+	if len(version) == 0 {
+		return ""
+	}
+	if len(info.Main.Path) == 0 {
+		return version
+	}
+	return fmt.Sprintf("%s@%s", info.Main.Path, version)
+}
 
+func (reporter *sentryReporter) getHub(level LogLevel)	*sentry.Hub	{
 	switch level {
-	case LOG_ERROR : return sentryError
-	case LOG_WARN :	return sentryWarn
-	case LOG_INFO :	return sentryInfo
+	case LOG_ERROR : return reporter.errorHub
+	case LOG_WARN :	return reporter.warnHub
+	case LOG_INFO :	return reporter.infoHub
 	}
 
 	return nil
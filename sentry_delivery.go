@@ -0,0 +1,226 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 5:30
+//
+
+package log
+
+import (
+	"fmt"
+	"github.com/getsentry/sentry-go"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sentry reporting runs on its own goroutine so that logging never waits on sentry sdk
+var sentryQueue = make(chan func(), DEFAULT_SENTRY_QUEUE_SIZE)
+var sentryPending int64
+var sentryDeliveryOnce sync.Once
+
+var sentryLimiter = newSentryRateLimiter()
+
+// breadcrumbs have their own buffer so that they never take queue slots of events
+var sentryBreadcrumbs = newSentryBreadcrumbBuffer(DEFAULT_SENTRY_BREADCRUMB_BUFFER_SIZE)
+
+func startSentryDelivery() {
+	sentryDeliveryOnce.Do(func() {
+		go func() {
+			for {
+				select {
+				case job := <-sentryQueue:
+					job()
+					atomic.AddInt64(&sentryPending, -1)
+				case <-sentryBreadcrumbs.signal:
+				}
+				sentryBreadcrumbs.applyIdle()
+			}
+		}()
+	})
+}
+
+// enqueueSentryJob returns false when queue is full
+func enqueueSentryJob(job func()) bool {
+	atomic.AddInt64(&sentryPending, 1)
+	select {
+	case sentryQueue <- job:
+		return true
+	default:
+		atomic.AddInt64(&sentryPending, -1)
+		return false
+	}
+}
+
+// enqueueSentryEvent applies rate limit and queues event job.
+// breadcrumbs recorded before the event are applied to hubs right before it is captured
+func enqueueSentryEvent(fingerprint string, job func()) {
+	if !sentryLimiter.allow(fingerprint, time.Now()) {
+		return
+	}
+
+	atomic.AddInt64(&sentryPending, 1)
+	barrier := sentryBreadcrumbs.barrier()
+	select {
+	case sentryQueue <- func() {
+		sentryBreadcrumbs.apply(barrier)
+		job()
+	}:
+		atomic.AddUint64(&loggerStats.SentryQueued, 1)
+	default:
+		atomic.AddInt64(&sentryPending, -1)
+		atomic.AddUint64(&loggerStats.SentryDropped, 1)
+	}
+}
+
+type queuedBreadcrumb struct {
+	seq        uint64
+	hubs       []*sentry.Hub
+	breadcrumb *sentry.Breadcrumb
+}
+
+// sentryBreadcrumbBuffer keeps breadcrumbs until delivery goroutine adds them to hubs.
+// oldest breadcrumb is dropped when buffer is full
+type sentryBreadcrumbBuffer struct {
+	mutex   sync.Mutex
+	size    int
+	items   []queuedBreadcrumb
+	nextSeq uint64
+	signal  chan struct{}
+}
+
+func newSentryBreadcrumbBuffer(size int) *sentryBreadcrumbBuffer {
+	return &sentryBreadcrumbBuffer{size: size, signal: make(chan struct{}, 1)}
+}
+
+func (buffer *sentryBreadcrumbBuffer) add(hubs []*sentry.Hub, breadcrumb *sentry.Breadcrumb) {
+	buffer.mutex.Lock()
+	if len(buffer.items) >= buffer.size {
+		buffer.items = buffer.items[1:]
+		atomic.AddUint64(&loggerStats.SentryBreadcrumbsDropped, 1)
+	}
+	buffer.items = append(buffer.items, queuedBreadcrumb{buffer.nextSeq, hubs, breadcrumb})
+	buffer.nextSeq++
+	buffer.mutex.Unlock()
+
+	select {
+	case buffer.signal <- struct{}{}:
+	default:
+	}
+}
+
+// barrier returns sequence of next breadcrumb. breadcrumbs before it precede the event
+func (buffer *sentryBreadcrumbBuffer) barrier() uint64 {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+	return buffer.nextSeq
+}
+
+// apply adds breadcrumbs recorded before barrier to their hubs
+func (buffer *sentryBreadcrumbBuffer) apply(barrier uint64) {
+	buffer.mutex.Lock()
+	n := 0
+	for n < len(buffer.items) && buffer.items[n].seq < barrier {
+		n++
+	}
+	items := buffer.items[:n]
+	buffer.items = buffer.items[n:]
+	buffer.mutex.Unlock()
+
+	for _, item := range items {
+		for _, hub := range item.hubs {
+			hub.AddBreadcrumb(item.breadcrumb, nil)
+		}
+	}
+}
+
+// applyIdle adds every buffered breadcrumb to hubs when no event is waiting in queue.
+// queued event should not see breadcrumbs recorded after it
+func (buffer *sentryBreadcrumbBuffer) applyIdle() {
+	barrier := buffer.barrier()
+	if atomic.LoadInt64(&sentryPending) == 0 {
+		buffer.apply(barrier)
+	}
+}
+
+// waitForSentryDelivered waits until queued jobs are handed over to sentry sdk
+func waitForSentryDelivered(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for atomic.LoadInt64(&sentryPending) > 0 {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond * 1)
+	}
+	return true
+}
+
+// countSentrySend counts result of capture. sentry sdk returns nil when it does not accept event
+func countSentrySend(eventId *sentry.EventID) {
+	if eventId == nil {
+		atomic.AddUint64(&loggerStats.SentryFailures, 1)
+	} else {
+		atomic.AddUint64(&loggerStats.SentrySent, 1)
+	}
+}
+
+func buildSentryFingerprint(level LogLevel, file string, line int, kind string) string {
+	return fmt.Sprintf("%d:%s:%d:%s", level, file, line, kind)
+}
+
+// sentryRateLimiter limits events per fingerprint and total events in one minute window
+type sentryRateLimiter struct {
+	mutex        sync.Mutex
+	windowStart  time.Time
+	total        int
+	fingerprints map[string]int
+}
+
+func newSentryRateLimiter() *sentryRateLimiter {
+	limiter := sentryRateLimiter{}
+	limiter.fingerprints = make(map[string]int)
+	return &limiter
+}
+
+func (limiter *sentryRateLimiter) allow(fingerprint string, now time.Time) bool {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if now.Sub(limiter.windowStart) >= time.Minute {
+		limiter.windowStart = now
+		limiter.total = 0
+		limiter.fingerprints = make(map[string]int)
+	}
+
+	if logPreference.sentryFingerprintLimit > 0 &&
+		limiter.fingerprints[fingerprint] >= logPreference.sentryFingerprintLimit {
+		atomic.AddUint64(&loggerStats.SentryRateLimited, 1)
+		return false
+	}
+
+	if logPreference.sentryEventsPerMinute > 0 && limiter.total >= logPreference.sentryEventsPerMinute {
+		atomic.AddUint64(&loggerStats.SentryBudgetExceeded, 1)
+		return false
+	}
+
+	limiter.fingerprints[fingerprint]++
+	limiter.total++
+	return true
+}
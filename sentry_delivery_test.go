@@ -0,0 +1,188 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 6:10
+//
+
+package log
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+func TestSentryRateLimiter(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	SetSentryRateLimit(2, 3)
+
+	limiter := newSentryRateLimiter()
+	now := time.Now()
+	results := []bool{
+		limiter.allow("a", now),
+		limiter.allow("a", now),
+		limiter.allow("a", now),
+		limiter.allow("b", now),
+		limiter.allow("c", now),
+	}
+	for i, expected := range []bool{true, true, false, true, false} {
+		if results[i] != expected {
+			t.Errorf("call %d : expected %v", i, expected)
+		}
+	}
+
+	// new window resets counters
+	if !limiter.allow("a", now.Add(time.Minute)) {
+		t.Errorf("limit should be reset in next window")
+	}
+}
+
+func TestSentryRateLimitPerFingerprint(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	SetSentryRateLimit(2, 0)
+	transport := installTestSentry(t, LOG_ERROR)
+	limited := atomic.LoadUint64(&loggerStats.SentryRateLimited)
+
+	for i := 0; i < 5; i++ {
+		Error("failed", errors.New("boom"))
+	}
+	Error("other place", errors.New("boom"))
+
+	if events := transport.Events(); len(events) != 3 {
+		t.Errorf("expected 2 events of repeated place and 1 of other place, got %d", len(events))
+	}
+	if n := atomic.LoadUint64(&loggerStats.SentryRateLimited) - limited; n != 3 {
+		t.Errorf("expected 3 rate limited events, got %d", n)
+	}
+}
+
+func TestSentryQueueDropsWhenFull(t *testing.T) {
+	startSentryDelivery()
+	dropped := atomic.LoadUint64(&loggerStats.SentryDropped)
+
+	// block delivery goroutine and fill queue
+	release := make(chan struct{})
+	started := make(chan struct{})
+	enqueueSentryJob(func() {
+		close(started)
+		<-release
+	})
+	<-started
+	for i := 0; i < DEFAULT_SENTRY_QUEUE_SIZE; i++ {
+		if !enqueueSentryJob(func() {}) {
+			t.Fatalf("queue should accept %d jobs", DEFAULT_SENTRY_QUEUE_SIZE)
+		}
+	}
+
+	enqueueSentryEvent("full", func() {
+		t.Errorf("dropped job should not run")
+	})
+	close(release)
+
+	if !waitForSentryDelivered(time.Second) {
+		t.Fatalf("queued jobs are not delivered")
+	}
+	if n := atomic.LoadUint64(&loggerStats.SentryDropped) - dropped; n != 1 {
+		t.Errorf("expected 1 dropped event, got %d", n)
+	}
+}
+
+func TestSentryBreadcrumbsDoNotTakeQueueSlots(t *testing.T) {
+	startSentryDelivery()
+	dropped := atomic.LoadUint64(&loggerStats.SentryDropped)
+
+	// block delivery goroutine and leave one slot for event
+	release := make(chan struct{})
+	started := make(chan struct{})
+	enqueueSentryJob(func() {
+		close(started)
+		<-release
+	})
+	<-started
+	for i := 0; i < DEFAULT_SENTRY_QUEUE_SIZE-1; i++ {
+		enqueueSentryJob(func() {})
+	}
+	for i := 0; i < DEFAULT_SENTRY_QUEUE_SIZE; i++ {
+		sentryBreadcrumbs.add(nil, &sentry.Breadcrumb{Message: "step"})
+	}
+
+	delivered := make(chan struct{})
+	enqueueSentryEvent("slot", func() {
+		close(delivered)
+	})
+	close(release)
+
+	select {
+	case <-delivered:
+	case <-time.After(time.Second):
+		t.Fatalf("event should be delivered")
+	}
+	if n := atomic.LoadUint64(&loggerStats.SentryDropped) - dropped; n != 0 {
+		t.Errorf("breadcrumbs should not drop events, got %d dropped", n)
+	}
+}
+
+func TestSentryBreadcrumbBufferDropsOldest(t *testing.T) {
+	buffer := newSentryBreadcrumbBuffer(2)
+	hub := sentry.NewHub(nil, sentry.NewScope())
+	dropped := GetStats().SentryBreadcrumbsDropped
+
+	for _, message := range []string{"first", "second", "third"} {
+		buffer.add([]*sentry.Hub{hub}, &sentry.Breadcrumb{Message: message})
+	}
+	if n := GetStats().SentryBreadcrumbsDropped - dropped; n != 1 {
+		t.Errorf("expected 1 dropped breadcrumb, got %d", n)
+	}
+
+	buffer.apply(buffer.barrier())
+	event := hub.Scope().ApplyToEvent(sentry.NewEvent(), nil)
+	if messages := breadcrumbMessages(event); len(messages) != 2 || messages[0] != "second" || messages[1] != "third" {
+		t.Errorf("oldest breadcrumb should be dropped, got %v", messages)
+	}
+}
+
+func TestSentryEventDoesNotCarryLaterBreadcrumbs(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	transport := installTestSentry(t, LOG_ERROR)
+
+	// block delivery goroutine while logging goes on
+	release := make(chan struct{})
+	started := make(chan struct{})
+	enqueueSentryJob(func() {
+		close(started)
+		<-release
+	})
+	<-started
+	Info("before")
+	Error("failed", errors.New("boom"))
+	Info("after")
+	close(release)
+
+	events := transport.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 sentry event, got %d", len(events))
+	}
+	if messages := breadcrumbMessages(events[0]); len(messages) != 1 || messages[0] != "before" {
+		t.Errorf("event should carry breadcrumbs recorded before it, got %v", messages)
+	}
+}
@@ -0,0 +1,339 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 5:30
+//
+
+package log
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// testSentryTransport keeps sentry events in memory
+type testSentryTransport struct {
+	mutex  sync.Mutex
+	events []*sentry.Event
+}
+
+func (transport *testSentryTransport) Configure(options sentry.ClientOptions) {}
+
+func (transport *testSentryTransport) SendEvent(event *sentry.Event) {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	transport.events = append(transport.events, event)
+}
+
+func (transport *testSentryTransport) Flush(timeout time.Duration) bool {
+	return true
+}
+
+// Events returns events after queued sentry jobs are delivered
+func (transport *testSentryTransport) Events() []*sentry.Event {
+	waitForSentryDelivered(time.Second)
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	return append([]*sentry.Event(nil), transport.events...)
+}
+
+// installTestSentry connects sentry hubs up to level to in-memory transport
+func installTestSentry(t *testing.T, level LogLevel) *testSentryTransport {
+	t.Helper()
+
+	transport := &testSentryTransport{}
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:            "https://key@sentry.invalid/1",
+		Transport:      transport,
+		MaxBreadcrumbs: logPreference.sentryMaxBreadcrumbs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newHub := func(sentryLevel sentry.Level) *sentry.Hub {
+		hub := sentry.NewHub(client, sentry.NewScope())
+		hub.Scope().SetLevel(sentryLevel)
+		return hub
+	}
+
+	logPreference.sentryLogLevel = level
+	reporter := &sentryReporter{}
+	if level >= LOG_INFO {
+		reporter.infoHub = newHub(sentry.LevelInfo)
+	}
+	if level >= LOG_WARN {
+		reporter.warnHub = newHub(sentry.LevelWarning)
+	}
+	if level >= LOG_ERROR {
+		reporter.errorHub = newHub(sentry.LevelError)
+	}
+	startSentryDelivery()
+	AddReporter(reporter)
+
+	t.Cleanup(func() {
+		waitForSentryDelivered(time.Second)
+		RemoveReporter(reporter)
+		sentryLimiter = newSentryRateLimiter()
+	})
+	return transport
+}
+
+func breadcrumbMessages(event *sentry.Event) []string {
+	messages := make([]string, 0, len(event.Breadcrumbs))
+	for _, breadcrumb := range event.Breadcrumbs {
+		messages = append(messages, breadcrumb.Message)
+	}
+	return messages
+}
+
+func TestSentryEventCarriesBreadcrumbs(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	transport := installTestSentry(t, LOG_ERROR)
+
+	Trace("too verbose")
+	Debug("step %d", 1)
+	Info("step %d", 2)
+	Error("failed", errors.New("boom"))
+
+	events := transport.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 sentry event, got %d", len(events))
+	}
+	messages := breadcrumbMessages(events[0])
+	if len(messages) != 2 || messages[0] != "step 1" || messages[1] != "step 2" {
+		t.Fatalf("unexpected breadcrumbs %v", messages)
+	}
+	breadcrumb := events[0].Breadcrumbs[1]
+	if breadcrumb.Category != "log" || breadcrumb.Level != sentry.LevelInfo {
+		t.Errorf("unexpected breadcrumb %+v", breadcrumb)
+	}
+}
+
+func TestSentryReportedEventIsNotBreadcrumb(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	transport := installTestSentry(t, LOG_WARN)
+
+	Info("step")
+	Warn("disk %d%%", 90)
+	Error("first", errors.New("boom"))
+	Error("second", errors.New("boom"))
+
+	events := transport.Events()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 sentry events, got %d", len(events))
+	}
+	for _, event := range events {
+		if messages := breadcrumbMessages(event); len(messages) != 1 || messages[0] != "step" {
+			t.Errorf("reported events should not be breadcrumbs of %s, got %v", event.Message, messages)
+		}
+	}
+}
+
+func TestSentryBreadcrumbLevelAndMax(t *testing.T) {
+	pref := newTestPreference(t)
+	initTestLogger(t, pref)
+	SetSentryBreadcrumbLevel("info")
+	SetSentryMaxBreadcrumbs(2)
+	transport := installTestSentry(t, LOG_ERROR)
+
+	Debug("hidden")
+	for i := 1; i <= 3; i++ {
+		InfoContext(WithFields(context.Background(), Fields{"step": i}), "step %d", i)
+	}
+	Error("failed", errors.New("boom"))
+
+	events := transport.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 sentry event, got %d", len(events))
+	}
+	messages := breadcrumbMessages(events[0])
+	if len(messages) != 2 || messages[0] != "step 2" || messages[1] != "step 3" {
+		t.Fatalf("only recent breadcrumbs over level should be kept, got %v", messages)
+	}
+	if events[0].Breadcrumbs[1].Data["step"] != 3 {
+		t.Errorf("fields should be breadcrumb data, got %v", events[0].Breadcrumbs[1].Data)
+	}
+}
+
+func TestSentryExceptionEventUsesTracePoints(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	transport := installTestSentry(t, LOG_ERROR)
+
+	_, file, line, _ := runtime.Caller(0)
+	Error("failed", errors.New("boom"))
+
+	events := transport.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 sentry event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Level != sentry.LevelError || event.Message != "failed" {
+		t.Errorf("unexpected event level %s, message %q", event.Level, event.Message)
+	}
+	if len(event.Exception) != 1 || event.Exception[0].Type != "*errors.errorString" || event.Exception[0].Value != "boom" {
+		t.Fatalf("unexpected exception %+v", event.Exception)
+	}
+
+	frames := event.Exception[0].Stacktrace.Frames
+	culprit := frames[len(frames)-1]
+	if culprit.AbsPath != file || culprit.Lineno != line+1 || culprit.Module != "throosea.com/log" {
+		t.Errorf("the most recent frame should be logging location, got %+v", culprit)
+	}
+	if culprit.Function != "TestSentryExceptionEventUsesTracePoints" || !culprit.InApp {
+		t.Errorf("unexpected culprit frame %+v", culprit)
+	}
+	if !strings.Contains(event.Transaction, "TestSentryExceptionEventUsesTracePoints") {
+		t.Errorf("transaction should be culprit function, got %s", event.Transaction)
+	}
+}
+
+func TestSentryPanicEventHasRecoveredError(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	transport := installTestSentry(t, LOG_ERROR)
+
+	func() {
+		defer Recover()
+		panicWith(errors.New("crash"))
+	}()
+
+	events := transport.Events()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 sentry event, got %d", len(events))
+	}
+	if events[0].Message != "panic recovered : crash" || events[0].Exception[0].Value != "crash" {
+		t.Errorf("unexpected exception %+v", events[0].Exception[0])
+	}
+	if events[0].Level != sentry.LevelFatal {
+		t.Errorf("panic event should be fatal, got %s", events[0].Level)
+	}
+}
+
+func TestSentryEventTags(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	transport := installTestSentry(t, LOG_ERROR)
+	reporter := reporters[len(reporters)-1].(*sentryReporter)
+	reporter.errorHub.Scope().SetTags(map[string]string{"process": "test", "zone": "a"})
+
+	ctx := WithTags(context.Background(), map[string]string{"zone": "b"})
+	ErrorContext(ctx, "context failed")
+	NewCustomLogger("error").WithTags(map[string]string{"tenant": "t1"}).Printf("logger failed")
+
+	events := transport.Events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 sentry events, got %d", len(events))
+	}
+	if tags := events[0].Tags; tags["process"] != "test" || tags["zone"] != "b" {
+		t.Errorf("context tags should override global tags, got %v", tags)
+	}
+	if tags := events[1].Tags; tags["zone"] != "a" || tags["tenant"] != "t1" {
+		t.Errorf("logger tags should be merged to global tags, got %v", tags)
+	}
+}
+
+func TestSentryInitReplacesReporter(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	SetSentryDsn("https://key@sentry.invalid/1", nil)
+	SetSentryLogLevel("error")
+	t.Cleanup(func() {
+		RemoveReporter(activeSentryReporter)
+		activeSentryReporter = nil
+		SetSentryDsn("", nil)
+	})
+
+	SentryInit()
+	SentryInit()
+
+	count := 0
+	reporterMutex.RLock()
+	for _, reporter := range reporters {
+		if _, ok := reporter.(*sentryReporter); ok {
+			count++
+		}
+	}
+	reporterMutex.RUnlock()
+	if count != 1 {
+		t.Errorf("expected 1 sentry reporter, got %d", count)
+	}
+}
+
+func TestSplitFunctionName(t *testing.T) {
+	tests := []struct {
+		name     string
+		module   string
+		function string
+	}{
+		{"throosea.com/log.(*logFile).write", "throosea.com/log", "(*logFile).write"},
+		{"main.main.func1", "main", "main.func1"},
+		{"net/http.(*conn).serve", "net/http", "(*conn).serve"},
+		{"nodot", "", "nodot"},
+	}
+	for _, test := range tests {
+		module, function := splitFunctionName(test.name)
+		if module != test.module || function != test.function {
+			t.Errorf("%s : got %s, %s", test.name, module, function)
+		}
+	}
+}
+
+func TestIsInAppFrame(t *testing.T) {
+	tests := []struct {
+		module string
+		file   string
+		inApp  bool
+	}{
+		{"main", "/app/main.go", true},
+		{"net/http", "/usr/local/go/src/net/http/server.go", false},
+		{"throosea.com/app", "/home/app/handler.go", true},
+		{"github.com/lib/pq", "/go/pkg/mod/github.com/lib/pq/conn.go", false},
+		{"github.com/lib/pq", "/app/vendor/github.com/lib/pq/conn.go", false},
+	}
+	for _, test := range tests {
+		if inApp := isInAppFrame(test.module, test.file); inApp != test.inApp {
+			t.Errorf("%s %s : expected %v", test.module, test.file, test.inApp)
+		}
+	}
+}
+
+func TestSentryInitRedactsTags(t *testing.T) {
+	resetTestRedaction(t)
+	pref := newTestPreference(t)
+	pref.Redaction = true
+	initTestLogger(t, pref)
+	SetSentryDsn("https://key@sentry.invalid/1", map[string]string{"owner": "bob@example.com", "region": "kr"})
+	SetSentryLogLevel("error")
+	t.Cleanup(func() {
+		RemoveReporter(activeSentryReporter)
+		activeSentryReporter = nil
+		SetSentryDsn("", nil)
+	})
+
+	SentryInit()
+	event := activeSentryReporter.errorHub.Scope().ApplyToEvent(sentry.NewEvent(), nil)
+	if event.Tags["owner"] != "***" || event.Tags["region"] != "kr" {
+		t.Errorf("sentry tags should be redacted, got %v", event.Tags)
+	}
+}
@@ -0,0 +1,395 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 9:00
+//
+
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type SyslogFormat uint8
+
+// syslog message format
+const (
+	SYSLOG_FORMAT_RFC5424 = 1 << iota
+	SYSLOG_FORMAT_RFC3164
+)
+
+// syslog facilities. constants carry set bit so that SYSLOG_FACILITY_KERN(0)
+// is distinguished from unset Facility which means SYSLOG_FACILITY_USER
+type SyslogFacility int
+
+const syslogFacilitySet SyslogFacility = 0x100
+
+const (
+	SYSLOG_FACILITY_KERN   = syslogFacilitySet | 0
+	SYSLOG_FACILITY_USER   = syslogFacilitySet | 1
+	SYSLOG_FACILITY_DAEMON = syslogFacilitySet | 3
+	SYSLOG_FACILITY_LOCAL0 = syslogFacilitySet | 16
+	SYSLOG_FACILITY_LOCAL1 = syslogFacilitySet | 17
+	SYSLOG_FACILITY_LOCAL2 = syslogFacilitySet | 18
+	SYSLOG_FACILITY_LOCAL3 = syslogFacilitySet | 19
+	SYSLOG_FACILITY_LOCAL4 = syslogFacilitySet | 20
+	SYSLOG_FACILITY_LOCAL5 = syslogFacilitySet | 21
+	SYSLOG_FACILITY_LOCAL6 = syslogFacilitySet | 22
+	SYSLOG_FACILITY_LOCAL7 = syslogFacilitySet | 23
+)
+
+// code returns facility code. 0 (unset) means user
+func (facility SyslogFacility) code() (int, error) {
+	if facility == 0 {
+		return 1, nil
+	}
+	code := int(facility &^ syslogFacilitySet)
+	if code < 0 || code > 23 {
+		return 0, fmt.Errorf("invalid syslog facility %d", code)
+	}
+	return code, nil
+}
+
+const (
+	// 32473 is example enterprise number reserved for documentation (RFC 5612)
+	DEFAULT_SYSLOG_SD_ID = "fields@32473"
+	syslogDialTimeout    = time.Second * 5
+	syslogWriteTimeout   = time.Second * 5
+	syslogMinBackoff     = time.Second
+	syslogMaxBackoff     = time.Second * 30
+	syslogPendingSize    = 64
+)
+
+var localSyslogPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogConfig is configuration of syslog sink
+type SyslogConfig struct {
+	Network          string         // "unixgram", "unix", "udp" or "tcp". empty means local syslog (/dev/log)
+	Address          string         // host:port or unix socket path
+	Format           SyslogFormat   // default SYSLOG_FORMAT_RFC5424
+	Facility         SyslogFacility // default SYSLOG_FACILITY_USER
+	Hostname         string         // default os.Hostname()
+	StructuredDataId string         // SD-ID of fields in RFC 5424. default fields@32473
+}
+
+// SyslogSink sends events to syslog daemon. when socket went away, it reconnects
+// in background. failed message and messages logged meanwhile are kept (up to 64)
+// and sent when connection is back
+type SyslogSink struct {
+	config       SyslogConfig
+	facility     int
+	appName      string
+	tag          string
+	mutex        sync.Mutex
+	network      string
+	conn         net.Conn
+	pending      []string
+	reconnecting bool
+	closed       bool
+	closeCh      chan struct{}
+}
+
+// NewSyslogSink connects to syslog daemon. register it with AddSink
+func NewSyslogSink(config SyslogConfig) (*SyslogSink, error) {
+	if config.Format == 0 {
+		config.Format = SYSLOG_FORMAT_RFC5424
+	}
+	facility, err := config.Facility.code()
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Hostname) == 0 {
+		config.Hostname, _ = os.Hostname()
+	}
+	if len(config.StructuredDataId) == 0 {
+		config.StructuredDataId = DEFAULT_SYSLOG_SD_ID
+	}
+
+	sink := SyslogSink{}
+	sink.config = config
+	sink.facility = facility
+	sink.appName = toSyslogToken(logPreference.ProcessName, 48)
+	// TAG of RFC 3164 is up to 32 characters
+	sink.tag = toSyslogToken(logPreference.ProcessName, 32)
+	sink.closeCh = make(chan struct{})
+	sink.conn, sink.network, err = sink.dial()
+	if err != nil {
+		return nil, err
+	}
+	return &sink, nil
+}
+
+func (sink *SyslogSink) dial() (net.Conn, string, error) {
+	if len(sink.config.Network) > 0 {
+		conn, err := net.DialTimeout(sink.config.Network, sink.config.Address, syslogDialTimeout)
+		if err != nil {
+			return nil, "", err
+		}
+		return conn, sink.config.Network, nil
+	}
+
+	// local syslog
+	paths := localSyslogPaths
+	if len(sink.config.Address) > 0 {
+		paths = []string{sink.config.Address}
+	}
+	for _, path := range paths {
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err := net.DialTimeout(network, path, syslogDialTimeout)
+			if err == nil {
+				return conn, network, nil
+			}
+		}
+	}
+	return nil, "", errors.New("unix syslog delivery error")
+}
+
+// Write never dials on caller goroutine. message is kept while reconnecting
+func (sink *SyslogSink) Write(event LogEvent) error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	if sink.closed {
+		atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+		return errors.New("syslog sink is closed")
+	}
+
+	message := sink.format(event)
+	if sink.conn == nil {
+		sink.keep(message)
+		return nil
+	}
+
+	if err := sink.send(message); err != nil {
+		sink.conn.Close()
+		sink.conn = nil
+		sink.keep(message)
+		sink.startReconnect()
+		return err
+	}
+	return nil
+}
+
+// send writes framed message. mutex should be held
+func (sink *SyslogSink) send(message string) error {
+	sink.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout))
+	_, err := sink.conn.Write(sink.frame(message))
+	return err
+}
+
+// keep holds message until reconnected. oldest message is dropped when full. mutex should be held
+func (sink *SyslogSink) keep(message string) {
+	if len(sink.pending) >= syslogPendingSize {
+		sink.pending = sink.pending[1:]
+		atomic.AddUint64(&loggerStats.DeliveryDropped, 1)
+	}
+	sink.pending = append(sink.pending, message)
+}
+
+// sendPending sends kept messages in order. mutex should be held
+func (sink *SyslogSink) sendPending() error {
+	for len(sink.pending) > 0 {
+		if err := sink.send(sink.pending[0]); err != nil {
+			return err
+		}
+		sink.pending = sink.pending[1:]
+	}
+	sink.pending = nil
+	return nil
+}
+
+func (sink *SyslogSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+
+	if sink.closed {
+		return nil
+	}
+	sink.closed = true
+	close(sink.closeCh)
+	atomic.AddUint64(&loggerStats.DeliveryDropped, uint64(len(sink.pending)))
+	sink.pending = nil
+
+	if sink.conn == nil {
+		return nil
+	}
+	err := sink.conn.Close()
+	sink.conn = nil
+	return err
+}
+
+// startReconnect starts reconnecting goroutine. mutex should be held
+func (sink *SyslogSink) startReconnect() {
+	if sink.reconnecting || sink.closed {
+		return
+	}
+	sink.reconnecting = true
+	go sink.reconnect()
+}
+
+func (sink *SyslogSink) reconnect() {
+	backoff := syslogMinBackoff
+	for {
+		conn, network, err := sink.dial()
+
+		sink.mutex.Lock()
+		if sink.closed {
+			sink.reconnecting = false
+			sink.mutex.Unlock()
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if err == nil {
+			sink.conn = conn
+			sink.network = network
+			if err = sink.sendPending(); err == nil {
+				sink.reconnecting = false
+				sink.mutex.Unlock()
+				return
+			}
+			sink.conn.Close()
+			sink.conn = nil
+		}
+		sink.mutex.Unlock()
+
+		select {
+		case <-time.After(backoff):
+		case <-sink.closeCh:
+		}
+		if backoff < syslogMaxBackoff {
+			backoff *= 2
+		}
+	}
+}
+
+func (sink *SyslogSink) isLocal() bool {
+	return len(sink.config.Network) == 0
+}
+
+func (sink *SyslogSink) format(event LogEvent) string {
+	priority := sink.facility*8 + toSyslogSeverity(event.Level())
+	message := event.Message()
+	if event.Err() != nil {
+		message = fmt.Sprintf("%s : %s", message, event.Err().Error())
+	}
+	// keep one event in one line
+	message = strings.Replace(message, "\n", " ", -1)
+
+	if sink.config.Format == SYSLOG_FORMAT_RFC3164 {
+		// local syslog daemon fills hostname
+		if sink.isLocal() {
+			return fmt.Sprintf("<%d>%s %s[%d]: %s",
+				priority, event.Time().Format(time.Stamp), sink.tag, os.Getpid(), message)
+		}
+		return fmt.Sprintf("<%d>%s %s %s[%d]: %s",
+			priority, event.Time().Format(time.Stamp), sink.config.Hostname, sink.tag, os.Getpid(), message)
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d - %s %s",
+		priority,
+		event.Time().Format("2006-01-02T15:04:05.000000Z07:00"),
+		toSyslogToken(sink.config.Hostname, 255),
+		sink.appName,
+		os.Getpid(),
+		sink.buildStructuredData(event.Fields()),
+		message)
+}
+
+// frame applies transport framing. stream transport needs delimiter between messages
+func (sink *SyslogSink) frame(message string) []byte {
+	switch sink.network {
+	case "tcp", "tcp4", "tcp6":
+		if sink.config.Format == SYSLOG_FORMAT_RFC5424 {
+			// octet counting (RFC 6587)
+			return []byte(fmt.Sprintf("%d %s", len(message), message))
+		}
+		return []byte(message + "\n")
+	case "unix":
+		return []byte(message + "\n")
+	}
+	return []byte(message)
+}
+
+// buildStructuredData builds RFC 5424 STRUCTURED-DATA from fields
+func (sink *SyslogSink) buildStructuredData(fields Fields) string {
+	if len(fields) == 0 {
+		return "-"
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('[')
+	buffer.WriteString(sink.config.StructuredDataId)
+	for _, k := range sortedFieldKeys(fields) {
+		buffer.WriteByte(' ')
+		buffer.WriteString(toSyslogParamName(k))
+		buffer.WriteString("=\"")
+		buffer.WriteString(escapeSyslogParamValue(fmt.Sprintf("%v", fields[k])))
+		buffer.WriteByte('"')
+	}
+	buffer.WriteByte(']')
+	return buffer.String()
+}
+
+func toSyslogSeverity(level LogLevel) int {
+	switch level {
+	case LOG_ERROR:
+		return 3
+	case LOG_WARN:
+		return 4
+	case LOG_INFO:
+		return 6
+	}
+	return 7
+}
+
+// toSyslogToken returns printable US-ASCII token without space (or "-" for empty)
+func toSyslogToken(s string, maxLength int) string {
+	var buffer bytes.Buffer
+	for i := 0; i < len(s) && buffer.Len() < maxLength; i++ {
+		if s[i] > 32 && s[i] < 127 {
+			buffer.WriteByte(s[i])
+		} else {
+			buffer.WriteByte('_')
+		}
+	}
+	if buffer.Len() == 0 {
+		return "-"
+	}
+	return buffer.String()
+}
+
+func toSyslogParamName(name string) string {
+	token := toSyslogToken(name, 32)
+	return strings.NewReplacer("=", "_", "]", "_", "\"", "_").Replace(token)
+}
+
+func escapeSyslogParamValue(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "]", "\\]").Replace(value)
+}
@@ -0,0 +1,352 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 9:20
+//
+
+package log
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listenTestUdp returns udp listener and function which reads next datagram
+func listenTestUdp(t *testing.T) (string, func() string) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn.LocalAddr().String(), func() string {
+		buffer := make([]byte, 8192)
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			t.Fatalf("fail to read datagram : %s", err)
+		}
+		return string(buffer[:n])
+	}
+}
+
+func TestSyslogRfc5424(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, read := listenTestUdp(t)
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "udp", Address: address, Facility: SYSLOG_FACILITY_LOCAL0, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_WARN, Fields{"user": "bob", "q": "a\"]"}, "disk\nfull"))
+	message := read()
+
+	// local0(16) * 8 + warning(4)
+	prefix := "<132>1 "
+	suffix := fmt.Sprintf(" host1 test %d - [fields@32473 q=\"a\\\"\\]\" user=\"bob\"] disk full", os.Getpid())
+	if !strings.HasPrefix(message, prefix) || !strings.HasSuffix(message, suffix) {
+		t.Errorf("unexpected message %q", message)
+	}
+}
+
+func TestSyslogRfc5424WithoutFields(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, read := listenTestUdp(t)
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "udp", Address: address, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_ERROR, nil, "failed", errors.New("boom")))
+	message := read()
+
+	if !strings.HasPrefix(message, "<11>1 ") || !strings.HasSuffix(message, " - - failed : boom") {
+		t.Errorf("unexpected message %q", message)
+	}
+}
+
+func TestSyslogRfc3164OverTcp(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	lines := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			lines <- line
+		}
+	}()
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "tcp", Address: listener.Addr().String(), Format: SYSLOG_FORMAT_RFC3164, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_INFO, nil, "first"))
+	sink.Write(newTestEvent(LOG_DEBUG, nil, "second"))
+
+	for _, expected := range []string{"<14>", "<15>"} {
+		select {
+		case line := <-lines:
+			suffix := fmt.Sprintf(" host1 test[%d]: ", os.Getpid())
+			if !strings.HasPrefix(line, expected) || !strings.Contains(line, suffix) || !strings.HasSuffix(line, "\n") {
+				t.Errorf("unexpected line %q", line)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no message received")
+		}
+	}
+}
+
+func TestSyslogRfc5424OctetCounting(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var length int
+		reader := bufio.NewReader(conn)
+		if _, err := fmt.Fscanf(reader, "%d ", &length); err != nil {
+			return
+		}
+		buffer := make([]byte, length)
+		if _, err := reader.Read(buffer); err == nil {
+			received <- string(buffer)
+		}
+	}()
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "tcp", Address: listener.Addr().String(), Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	sink.Write(newTestEvent(LOG_INFO, nil, "counted"))
+
+	select {
+	case message := <-received:
+		if !strings.HasPrefix(message, "<14>1 ") || !strings.HasSuffix(message, " - - counted") {
+			t.Errorf("unexpected message %q", message)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("no message received")
+	}
+}
+
+func TestSyslogLocalSocket(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	path := filepath.Join(t.TempDir(), "log.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram is not supported : %s", err)
+	}
+	defer conn.Close()
+
+	sink, err := NewSyslogSink(SyslogConfig{Address: path, Format: SYSLOG_FORMAT_RFC3164, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_ERROR, nil, "local"))
+	buffer := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// local syslog daemon fills hostname
+	message := string(buffer[:n])
+	if !strings.HasPrefix(message, "<11>") || strings.Contains(message, "host1") || !strings.HasSuffix(message, fmt.Sprintf(" test[%d]: local", os.Getpid())) {
+		t.Errorf("unexpected message %q", message)
+	}
+}
+
+func TestSyslogInvalidConfig(t *testing.T) {
+	if _, err := NewSyslogSink(SyslogConfig{Facility: 24}); err == nil {
+		t.Errorf("invalid facility should be rejected")
+	}
+	if _, err := NewSyslogSink(SyslogConfig{Address: filepath.Join(t.TempDir(), "none.sock")}); err == nil {
+		t.Errorf("missing local socket should be rejected")
+	}
+}
+
+func TestSinkReceivesLoggedEvents(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, read := listenTestUdp(t)
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "udp", Address: address, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	AddSink(sink)
+	defer func() {
+		RemoveSink(sink)
+		sink.Close()
+	}()
+
+	Info("from logger")
+	if message := read(); !strings.HasSuffix(message, " - - from logger") {
+		t.Errorf("unexpected message %q", message)
+	}
+}
+
+func TestToSyslogToken(t *testing.T) {
+	tests := []struct {
+		s         string
+		maxLength int
+		expected  string
+	}{
+		{"app", 48, "app"},
+		{"my app", 48, "my_app"},
+		{"", 48, "-"},
+		{"abcdef", 3, "abc"},
+		{"한글", 48, "______"},
+	}
+	for _, test := range tests {
+		if token := toSyslogToken(test.s, test.maxLength); token != test.expected {
+			t.Errorf("toSyslogToken(%q, %d) = %q, expected %q", test.s, test.maxLength, token, test.expected)
+		}
+	}
+}
+
+func TestSyslogKernFacility(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	address, read := listenTestUdp(t)
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "udp", Address: address, Facility: SYSLOG_FACILITY_KERN, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_ERROR, nil, "kernel"))
+	if message := read(); !strings.HasPrefix(message, "<3>1 ") {
+		t.Errorf("kern facility should be kept, got %q", message)
+	}
+}
+
+func TestSyslogReconnectsInBackground(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	path := filepath.Join(t.TempDir(), "log.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram is not supported : %s", err)
+	}
+
+	sink, err := NewSyslogSink(SyslogConfig{Address: path, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	// syslog daemon restarts
+	conn.Close()
+	os.Remove(path)
+	start := time.Now()
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, "failed")); err == nil {
+		t.Fatalf("write to closed socket should fail")
+	}
+	if err := sink.Write(newTestEvent(LOG_INFO, nil, "kept")); err != nil {
+		t.Fatalf("message should be kept while reconnecting : %s", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("write should not wait for reconnect")
+	}
+
+	conn, err = net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// failed and kept messages are sent in order when reconnected
+	buffer := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	for _, expected := range []string{"failed", "kept"} {
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil || !strings.HasSuffix(string(buffer[:n]), " - - "+expected) {
+			t.Fatalf("expected %s, got %q %v", expected, buffer[:n], err)
+		}
+	}
+}
+
+func TestSyslogRfc3164TagLength(t *testing.T) {
+	pref := newTestPreference(t)
+	pref.ProcessName = strings.Repeat("p", 40)
+	initTestLogger(t, pref)
+	address, read := listenTestUdp(t)
+
+	sink, err := NewSyslogSink(SyslogConfig{Network: "udp", Address: address, Format: SYSLOG_FORMAT_RFC3164, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	sink.Write(newTestEvent(LOG_INFO, nil, "hello"))
+	if message := read(); !strings.Contains(message, fmt.Sprintf(" host1 %s[%d]: hello", strings.Repeat("p", 32), os.Getpid())) {
+		t.Errorf("TAG should be truncated to 32 characters, got %q", message)
+	}
+
+	sink5424, err := NewSyslogSink(SyslogConfig{Network: "udp", Address: address, Hostname: "host1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink5424.Close()
+
+	sink5424.Write(newTestEvent(LOG_INFO, nil, "hello"))
+	if message := read(); !strings.Contains(message, " host1 "+strings.Repeat("p", 40)+" ") {
+		t.Errorf("APP-NAME should keep up to 48 characters, got %q", message)
+	}
+}
@@ -0,0 +1,228 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 8:10
+//
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	DEFAULT_WEBHOOK_TRACE_LINES = 5
+	DEFAULT_WEBHOOK_BATCH_SIZE  = 20
+	DEFAULT_WEBHOOK_TIMEOUT     = time.Second * 10
+)
+
+// WebhookConfig is configuration of webhook reporter
+type WebhookConfig struct {
+	Url         string            // incoming webhook url
+	Template    string            // text/template of request body with WebhookAlert. default json body
+	ContentType string            // default application/json
+	Headers     map[string]string // additional request headers
+	Level       LogLevel          // max level to report. default LOG_ERROR
+	Window      time.Duration     // events within window are sent in one request. default 5 seconds
+	MaxBatch    int               // max events in one request. default 20
+	TraceLines  int               // max trace lines per event. default 5
+	Timeout     time.Duration     // http request timeout. default 10 seconds
+}
+
+// WebhookAlert is data of webhook request body
+type WebhookAlert struct {
+	Process string         `json:"process"`
+	Host    string         `json:"host"`
+	Text    string         `json:"text"`
+	Events  []WebhookEvent `json:"events"`
+}
+
+// WebhookEvent is event data in webhook request body
+type WebhookEvent struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+	Source  string    `json:"source"`
+	Error   string    `json:"error,omitempty"`
+	Trace   []string  `json:"trace,omitempty"`
+}
+
+// WebhookReporter posts events to incoming webhook (e.g. chat notification)
+type WebhookReporter struct {
+	config   WebhookConfig
+	template *template.Template
+	client   *http.Client
+	host     string
+	worker   *batchWorker
+}
+
+// NewWebhookReporter creates webhook reporter. register it with AddReporter
+func NewWebhookReporter(config WebhookConfig) (*WebhookReporter, error) {
+	if len(config.Url) == 0 {
+		return nil, fmt.Errorf("empty webhook url")
+	}
+	if config.Level == LOG_NONE {
+		config.Level = LOG_ERROR
+	}
+	if len(config.ContentType) == 0 {
+		config.ContentType = "application/json"
+	}
+	if config.MaxBatch < 1 {
+		config.MaxBatch = DEFAULT_WEBHOOK_BATCH_SIZE
+	}
+	if config.TraceLines < 1 {
+		config.TraceLines = DEFAULT_WEBHOOK_TRACE_LINES
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DEFAULT_WEBHOOK_TIMEOUT
+	}
+
+	reporter := WebhookReporter{}
+	reporter.config = config
+	reporter.client = &http.Client{Timeout: config.Timeout}
+	reporter.host, _ = os.Hostname()
+
+	if len(config.Template) > 0 {
+		tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+			"json": func(v interface{}) (string, error) {
+				b, err := json.Marshal(v)
+				return string(b), err
+			},
+			"join": strings.Join,
+		}).Parse(config.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook template : %s", err.Error())
+		}
+		reporter.template = tmpl
+	}
+
+	reporter.worker = newBatchWorker(config.MaxBatch, config.Window, reporter.post)
+	return &reporter, nil
+}
+
+func (reporter *WebhookReporter) ReportMessage(event LogEvent) {
+	if reporter.config.Level >= event.Level() {
+		reporter.worker.add(event)
+	}
+}
+
+func (reporter *WebhookReporter) ReportException(event LogEvent) {
+	if reporter.config.Level >= event.Level() {
+		reporter.worker.add(event)
+	}
+}
+
+func (reporter *WebhookReporter) Flush(timeout time.Duration) bool {
+	return reporter.worker.flush(timeout)
+}
+
+// Close sends queued events within ReporterFlushTimeout and stops delivery goroutine.
+// RemoveReporter and log.Close() call it. later calls return result of the first call
+func (reporter *WebhookReporter) Close() error {
+	if !reporter.worker.stop(logPreference.ReporterFlushTimeout) {
+		return errors.New("webhook events are not delivered within timeout")
+	}
+	return nil
+}
+
+func (reporter *WebhookReporter) buildAlert(events []LogEvent) WebhookAlert {
+	alert := WebhookAlert{}
+	alert.Process = logPreference.ProcessName
+	alert.Host = reporter.host
+
+	var text bytes.Buffer
+	text.WriteString(fmt.Sprintf("[%s@%s] %d event(s)", alert.Process, alert.Host, len(events)))
+	for _, event := range events {
+		item := WebhookEvent{}
+		item.Time = event.Time()
+		item.Level = event.Level().String()
+		item.Message = event.Message()
+		item.Source = fmt.Sprintf("%s (%s:%d)", event.Function(), buildSourcePath(event.File()), event.Line())
+		if event.Err() != nil {
+			item.Error = event.Err().Error()
+		}
+		for i, point := range event.Trace() {
+			if i >= reporter.config.TraceLines {
+				break
+			}
+			item.Trace = append(item.Trace, fmt.Sprintf("%s (%s:%d)", point.Function(), buildSourcePath(point.File()), point.Line()))
+		}
+		alert.Events = append(alert.Events, item)
+		text.WriteString(fmt.Sprintf("\n%s %s : %s", item.Level, item.Source, item.Message))
+	}
+	alert.Text = text.String()
+	return alert
+}
+
+func (reporter *WebhookReporter) post(events []LogEvent) error {
+	alert := reporter.buildAlert(events)
+
+	var body bytes.Buffer
+	if reporter.template != nil {
+		if err := reporter.template.Execute(&body, alert); err != nil {
+			return noRetry(err)
+		}
+	} else {
+		if err := json.NewEncoder(&body).Encode(alert); err != nil {
+			return noRetry(err)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, reporter.config.Url, &body)
+	if err != nil {
+		return noRetry(err)
+	}
+	req.Header.Set("Content-Type", reporter.config.ContentType)
+	for k, v := range reporter.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := reporter.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	return checkHttpResponse(resp)
+}
+
+// checkHttpResponse returns error for non 2xx response. 4xx except 429 is not retried
+func checkHttpResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err := fmt.Errorf("http response %s", resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return noRetry(err)
+	}
+	return err
+}
@@ -0,0 +1,308 @@
+//
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+// @project fatima
+// @author DeockJin Chung (jin.freestyle@gmail.com)
+// @date 2026. 10. 19. PM 8:30
+//
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testWebhookServer records request bodies and answers with given status codes in order
+type testWebhookServer struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	bodies   []string
+	headers  []http.Header
+	times    []time.Time
+	statuses []int
+}
+
+func newTestWebhookServer(t *testing.T, statuses ...int) *testWebhookServer {
+	server := &testWebhookServer{statuses: statuses}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		server.mutex.Lock()
+		server.bodies = append(server.bodies, string(body))
+		server.headers = append(server.headers, r.Header.Clone())
+		server.times = append(server.times, time.Now())
+		status := http.StatusOK
+		if len(server.statuses) > 0 {
+			status = server.statuses[0]
+			server.statuses = server.statuses[1:]
+		}
+		server.mutex.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (server *testWebhookServer) requests() ([]string, []http.Header, []time.Time) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]string(nil), server.bodies...), append([]http.Header(nil), server.headers...), append([]time.Time(nil), server.times...)
+}
+
+func TestWebhookJsonBody(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	server := newTestWebhookServer(t)
+
+	reporter, err := NewWebhookReporter(WebhookConfig{
+		Url:     server.URL,
+		Headers: map[string]string{"X-Token": "secret"},
+		Window:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reporter.Close()
+	reporter.ReportMessage(newTestEvent(LOG_INFO, nil, "ignored"))
+	reporter.ReportException(newTestEvent(LOG_ERROR, nil, "failed", errors.New("boom")))
+	if !reporter.Flush(time.Second) {
+		t.Fatalf("flush timeout")
+	}
+
+	bodies, headers, _ := server.requests()
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(bodies))
+	}
+	if headers[0].Get("Content-Type") != "application/json" || headers[0].Get("X-Token") != "secret" {
+		t.Errorf("unexpected headers %v", headers[0])
+	}
+
+	var alert WebhookAlert
+	if err := json.Unmarshal([]byte(bodies[0]), &alert); err != nil {
+		t.Fatalf("invalid json body %s : %s", bodies[0], err)
+	}
+	if alert.Process != "test" || len(alert.Events) != 1 {
+		t.Fatalf("unexpected alert %+v", alert)
+	}
+	event := alert.Events[0]
+	if event.Level != "ERROR" || event.Message != "failed" || event.Error != "boom" || len(event.Trace) != 1 {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if !strings.Contains(event.Source, "webhook_test.go") {
+		t.Errorf("unexpected source %s", event.Source)
+	}
+}
+
+func TestWebhookTemplateBody(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	server := newTestWebhookServer(t)
+
+	reporter, err := NewWebhookReporter(WebhookConfig{
+		Url:         server.URL,
+		Template:    `{"text":{{json .Text}},"count":{{len .Events}}}`,
+		ContentType: "application/x-test",
+		Level:       LOG_WARN,
+		Window:      time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reporter.Close()
+	reporter.ReportMessage(newTestEvent(LOG_WARN, nil, "disk %d%%", 90))
+	reporter.Flush(time.Second)
+
+	bodies, headers, _ := server.requests()
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(bodies))
+	}
+	if headers[0].Get("Content-Type") != "application/x-test" {
+		t.Errorf("unexpected content type %s", headers[0].Get("Content-Type"))
+	}
+
+	var body struct {
+		Text  string
+		Count int
+	}
+	if err := json.Unmarshal([]byte(bodies[0]), &body); err != nil {
+		t.Fatalf("invalid template body %s : %s", bodies[0], err)
+	}
+	if body.Count != 1 || !strings.HasPrefix(body.Text, "[test@") || !strings.HasSuffix(body.Text, " : disk 90%") {
+		t.Errorf("unexpected template body %s", bodies[0])
+	}
+}
+
+func TestWebhookInvalidConfig(t *testing.T) {
+	if _, err := NewWebhookReporter(WebhookConfig{}); err == nil {
+		t.Errorf("empty url should be rejected")
+	}
+	if _, err := NewWebhookReporter(WebhookConfig{Url: "http://localhost", Template: "{{"}); err == nil {
+		t.Errorf("invalid template should be rejected")
+	}
+}
+
+func TestWebhookBatchesEventsWithinWindow(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	server := newTestWebhookServer(t)
+
+	reporter, err := NewWebhookReporter(WebhookConfig{Url: server.URL, Window: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reporter.Close()
+	for i := 0; i < 3; i++ {
+		reporter.ReportMessage(newTestEvent(LOG_ERROR, nil, "failed %d", i))
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if bodies, _, _ := server.requests(); len(bodies) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	bodies, _, _ := server.requests()
+	if len(bodies) != 1 {
+		t.Fatalf("events within window should be sent in 1 request, got %d", len(bodies))
+	}
+	var alert WebhookAlert
+	json.Unmarshal([]byte(bodies[0]), &alert)
+	if len(alert.Events) != 3 {
+		t.Errorf("expected 3 events in batch, got %d", len(alert.Events))
+	}
+}
+
+func TestWebhookMaxBatch(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	server := newTestWebhookServer(t)
+
+	reporter, err := NewWebhookReporter(WebhookConfig{Url: server.URL, Window: time.Minute, MaxBatch: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reporter.Close()
+	for i := 0; i < 3; i++ {
+		reporter.ReportMessage(newTestEvent(LOG_ERROR, nil, "failed %d", i))
+	}
+	reporter.Flush(time.Second)
+
+	if bodies, _, _ := server.requests(); len(bodies) != 2 {
+		t.Errorf("expected 2 requests by max batch, got %d", len(bodies))
+	}
+}
+
+func TestWebhookBackoffAfterServerError(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	server := newTestWebhookServer(t, http.StatusServiceUnavailable)
+	failures := atomic.LoadUint64(&loggerStats.DeliveryFailures)
+
+	reporter, err := NewWebhookReporter(WebhookConfig{Url: server.URL, Window: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reporter.Close()
+	reporter.ReportMessage(newTestEvent(LOG_ERROR, nil, "failed"))
+	if !reporter.Flush(5 * time.Second) {
+		t.Fatalf("flush timeout")
+	}
+
+	bodies, _, times := server.requests()
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Fatalf("failed request should be retried with same body, got %d requests", len(bodies))
+	}
+	if elapsed := times[1].Sub(times[0]); elapsed < DEFAULT_BATCH_MIN_BACKOFF {
+		t.Errorf("retry should wait backoff, waited %s", elapsed)
+	}
+	if n := atomic.LoadUint64(&loggerStats.DeliveryFailures) - failures; n != 1 {
+		t.Errorf("expected 1 delivery failure, got %d", n)
+	}
+}
+
+func TestWebhookClientErrorIsNotRetried(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	server := newTestWebhookServer(t, http.StatusBadRequest)
+	dropped := atomic.LoadUint64(&loggerStats.DeliveryDropped)
+
+	reporter, err := NewWebhookReporter(WebhookConfig{Url: server.URL, Window: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reporter.Close()
+	reporter.ReportMessage(newTestEvent(LOG_ERROR, nil, "failed"))
+	reporter.Flush(time.Second)
+
+	if bodies, _, _ := server.requests(); len(bodies) != 1 {
+		t.Errorf("4xx response should not be retried, got %d requests", len(bodies))
+	}
+	if n := atomic.LoadUint64(&loggerStats.DeliveryDropped) - dropped; n != 1 {
+		t.Errorf("expected 1 dropped event, got %d", n)
+	}
+}
+
+func TestWebhookRemoveReporterSendsQueuedEvents(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	server := newTestWebhookServer(t)
+
+	reporter, err := NewWebhookReporter(WebhookConfig{Url: server.URL, Window: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	AddReporter(reporter)
+	Error("failed")
+	RemoveReporter(reporter)
+
+	if bodies, _, _ := server.requests(); len(bodies) != 1 {
+		t.Errorf("queued event should be sent on remove, got %d requests", len(bodies))
+	}
+	if err := reporter.Close(); err != nil {
+		t.Errorf("second close should return result of the first : %s", err)
+	}
+}
+
+func TestCloseClosesWebhookReporter(t *testing.T) {
+	initTestLogger(t, newTestPreference(t))
+	server := newTestWebhookServer(t)
+
+	reporter, err := NewWebhookReporter(WebhookConfig{Url: server.URL, Window: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	AddReporter(reporter)
+	Error("failed")
+	Close()
+
+	if bodies, _, _ := server.requests(); len(bodies) != 1 {
+		t.Errorf("queued event should be sent on close, got %d requests", len(bodies))
+	}
+	reporterMutex.RLock()
+	defer reporterMutex.RUnlock()
+	for _, r := range reporters {
+		if r == Reporter(reporter) {
+			t.Errorf("closed reporter should be unregistered")
+		}
+	}
+}